@@ -0,0 +1,150 @@
+package woocommerce
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	authorizeEndpoint = "/wc-auth/v1/authorize"
+
+	// maxAuthCallbackSize bounds the callback body; the real payload is a few hundred bytes.
+	maxAuthCallbackSize = 64 << 10
+)
+
+// Scopes accepted by the WooCommerce authentication endpoint.
+const (
+	ScopeRead      = "read"
+	ScopeWrite     = "write"
+	ScopeReadWrite = "read_write"
+)
+
+// AuthCallback is the payload WooCommerce POSTs to App.CallbackUrl once a
+// merchant approves the app.
+// https://woocommerce.github.io/woocommerce-rest-api-docs/#auth-endpoint
+type AuthCallback struct {
+	KeyID          int64  `json:"key_id"`
+	UserID         string `json:"-"`
+	ConsumerKey    string `json:"consumer_key"`
+	ConsumerSecret string `json:"consumer_secret"`
+	KeyPermissions string `json:"key_permissions"`
+}
+
+// UnmarshalJSON accepts user_id as either a JSON string or number, since it
+// is echoed back exactly as the app sent it.
+func (a *AuthCallback) UnmarshalJSON(data []byte) error {
+	type alias AuthCallback
+	aux := struct {
+		*alias
+		UserID json.RawMessage `json:"user_id"`
+	}{alias: (*alias)(a)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	a.UserID = ""
+	if len(aux.UserID) == 0 || string(aux.UserID) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(aux.UserID, &s); err == nil {
+		a.UserID = s
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(aux.UserID, &n); err != nil {
+		return fmt.Errorf("invalid user_id %s", aux.UserID)
+	}
+	a.UserID = n.String()
+	return nil
+}
+
+// AuthorizeURL builds the URL a merchant visits to grant the app API keys,
+// using the app's AppName, Scope, UserId, ReturnUrl and CallbackUrl.
+// The shopName parameter is the shop's base URL, as passed to NewClient.
+func (a App) AuthorizeURL(shopName string) (string, error) {
+	if a.AppName == "" {
+		return "", errors.New("woocommerce auth: AppName is required")
+	}
+	if a.UserId == "" {
+		return "", errors.New("woocommerce auth: UserId is required")
+	}
+	switch a.Scope {
+	case ScopeRead, ScopeWrite, ScopeReadWrite:
+	default:
+		return "", fmt.Errorf("woocommerce auth: invalid scope %q", a.Scope)
+	}
+	if _, err := url.ParseRequestURI(a.ReturnUrl); err != nil {
+		return "", fmt.Errorf("woocommerce auth: invalid ReturnUrl: %w", err)
+	}
+	callback, err := url.ParseRequestURI(a.CallbackUrl)
+	if err != nil {
+		return "", fmt.Errorf("woocommerce auth: invalid CallbackUrl: %w", err)
+	}
+	// WooCommerce refuses to deliver keys to a callback that is not HTTPS.
+	if callback.Scheme != "https" {
+		return "", errors.New("woocommerce auth: CallbackUrl must use https")
+	}
+
+	base, err := url.Parse(shopName)
+	if err != nil {
+		return "", err
+	}
+	u := base.ResolveReference(&url.URL{Path: authorizeEndpoint})
+	u.RawQuery = url.Values{
+		"app_name":     {a.AppName},
+		"scope":        {a.Scope},
+		"user_id":      {a.UserId},
+		"return_url":   {a.ReturnUrl},
+		"callback_url": {a.CallbackUrl},
+	}.Encode()
+	return u.String(), nil
+}
+
+// AuthHandlerFunc receives a client configured with the keys delivered to
+// the callback. Returning an error makes the handler answer 500, which
+// WooCommerce reports to the merchant as a failed authorization.
+type AuthHandlerFunc func(client *Client, callback AuthCallback) error
+
+// NewAuthHandler returns an http.Handler to be mounted at App.CallbackUrl.
+// It accepts the callback POST, checks that user_id matches App.UserId and
+// hands a ready to use *Client for shopName, built with opts, to fn.
+func (a App) NewAuthHandler(shopName string, fn AuthHandlerFunc, opts ...Option) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		var callback AuthCallback
+		dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAuthCallbackSize))
+		if err := dec.Decode(&callback); err != nil {
+			http.Error(w, "invalid callback payload", http.StatusBadRequest)
+			return
+		}
+		if a.UserId == "" || callback.UserID != a.UserId {
+			http.Error(w, "unknown user_id", http.StatusForbidden)
+			return
+		}
+		if callback.ConsumerKey == "" || callback.ConsumerSecret == "" {
+			http.Error(w, "missing consumer credentials", http.StatusBadRequest)
+			return
+		}
+
+		app := a
+		app.CustomerKey = callback.ConsumerKey
+		app.CustomerSecret = callback.ConsumerSecret
+		app.JwtToken = ""
+		client := NewClient(app, shopName, opts...)
+
+		if err := fn(client, callback); err != nil {
+			client.log.Errorf("auth callback for user %s failed: %v", callback.UserID, err)
+			http.Error(w, "could not store credentials", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,108 @@
+package woocommerce
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func testAuthApp() App {
+	return App{
+		AppName:     "My App",
+		UserId:      "42",
+		Scope:       ScopeReadWrite,
+		ReturnUrl:   "https://app.example.com/done",
+		CallbackUrl: "https://app.example.com/wc/callback",
+	}
+}
+
+func TestApp_AuthorizeURL(t *testing.T) {
+	got, err := testAuthApp().AuthorizeURL("https://shop.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Host != "shop.example.com" || u.Path != "/wc-auth/v1/authorize" {
+		t.Errorf("unexpected url %s", got)
+	}
+	q := u.Query()
+	if q.Get("app_name") != "My App" || q.Get("scope") != "read_write" || q.Get("user_id") != "42" ||
+		q.Get("return_url") != "https://app.example.com/done" || q.Get("callback_url") != "https://app.example.com/wc/callback" {
+		t.Errorf("unexpected query %v", q)
+	}
+
+	app := testAuthApp()
+	app.Scope = "admin"
+	if _, err := app.AuthorizeURL("https://shop.example.com"); err == nil {
+		t.Error("expected error for invalid scope")
+	}
+	app = testAuthApp()
+	app.CallbackUrl = "http://app.example.com/wc/callback"
+	if _, err := app.AuthorizeURL("https://shop.example.com"); err == nil {
+		t.Error("expected error for non-https callback")
+	}
+}
+
+func TestApp_NewAuthHandler(t *testing.T) {
+	var gotClient *Client
+	var gotCallback AuthCallback
+	h := testAuthApp().NewAuthHandler("https://shop.example.com", func(c *Client, cb AuthCallback) error {
+		gotClient, gotCallback = c, cb
+		return nil
+	}, WithLog(&LeveledLogger{}))
+
+	body := `{"key_id":1,"user_id":42,"consumer_key":"ck_abc","consumer_secret":"cs_def","key_permissions":"read_write"}`
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/wc/callback", strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	if gotClient == nil || gotClient.app.CustomerKey != "ck_abc" || gotClient.app.CustomerSecret != "cs_def" {
+		t.Fatalf("client not configured with delivered keys: %+v", gotClient)
+	}
+	if gotClient.baseURL.Host != "shop.example.com" {
+		t.Errorf("client base url = %s", gotClient.baseURL)
+	}
+	if gotCallback.UserID != "42" || gotCallback.KeyPermissions != "read_write" || gotCallback.KeyID != 1 {
+		t.Errorf("unexpected callback %+v", gotCallback)
+	}
+}
+
+func TestApp_NewAuthHandler_Rejects(t *testing.T) {
+	called := false
+	h := testAuthApp().NewAuthHandler("https://shop.example.com", func(c *Client, cb AuthCallback) error {
+		called = true
+		return errors.New("storage down")
+	}, WithLog(&LeveledLogger{}))
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		want   int
+	}{
+		{"wrong method", http.MethodGet, "", http.StatusMethodNotAllowed},
+		{"bad json", http.MethodPost, "{", http.StatusBadRequest},
+		{"wrong user", http.MethodPost, `{"user_id":"7","consumer_key":"ck","consumer_secret":"cs"}`, http.StatusForbidden},
+		{"missing keys", http.MethodPost, `{"user_id":"42"}`, http.StatusBadRequest},
+		{"handler error", http.MethodPost, `{"user_id":"42","consumer_key":"ck","consumer_secret":"cs"}`, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, "/wc/callback", strings.NewReader(tt.body)))
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+	if !called {
+		t.Error("handler func never invoked for a valid callback")
+	}
+}
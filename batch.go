@@ -0,0 +1,370 @@
+package woocommerce
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// MaxBatchSize is the number of objects, creates, updates and deletes
+// together, that WooCommerce accepts in a single batch request.
+const MaxBatchSize = 100
+
+// BatchOption lists the objects to create, update and delete in a batch
+// request. Every service's XxxBatchOption is a BatchOption.
+// https://woocommerce.github.io/woocommerce-rest-api-docs/#batch-update-orders
+type BatchOption[T any] struct {
+	Create []T     `json:"create,omitempty"`
+	Update []T     `json:"update,omitempty"`
+	Delete []int64 `json:"delete,omitempty"`
+}
+
+// BatchResource is the response to a BatchOption request. Create[i] answers
+// Create[i] of the option, and so on; it is nil when that object failed, in
+// which case Items holds the reason.
+type BatchResource[T any] struct {
+	Create []*T `json:"create,omitempty"`
+	Update []*T `json:"update,omitempty"`
+	Delete []*T `json:"delete,omitempty"`
+
+	// Items lists the outcome of every object, creates first, then updates
+	// and deletes.
+	Items []BatchItem[T] `json:"-"`
+}
+
+// BatchOp is the list of a BatchOption an object belongs to.
+type BatchOp string
+
+const (
+	BatchCreate BatchOp = "create"
+	BatchUpdate BatchOp = "update"
+	BatchDelete BatchOp = "delete"
+)
+
+// BatchItem is the outcome of one object of a batch: either Item or Err is set.
+type BatchItem[T any] struct {
+	Op BatchOp
+	// Index is the position of the object in the Op list of the BatchOption.
+	Index int
+	Item  *T
+	// Err is a *BatchItemError when WooCommerce rejected the object, or the
+	// *BatchChunkError of the request that carried it.
+	Err error
+}
+
+// BatchItemError is the error WooCommerce reports for a single object of a
+// batch, e.g. {"id": 12, "error": {"code": "woocommerce_rest_shop_order_invalid_id", ...}}.
+type BatchItemError struct {
+	// ID is the object's ID as echoed by WooCommerce, 0 for failed creates.
+	ID      int64
+	Code    string
+	Message string
+	Status  int
+	Data    map[string]interface{}
+}
+
+func (e *BatchItemError) Error() string {
+	return fmt.Sprintf("%d: %s (%s)", e.Status, e.Message, e.Code)
+}
+
+// Is matches the sentinel errors, such as ErrNotFound, for the item's status and code.
+func (e *BatchItemError) Is(target error) bool {
+	return errorMatches(target, e.Status, e.Code, false)
+}
+
+// Failed returns the items that failed.
+func (r *BatchResource[T]) Failed() []BatchItem[T] {
+	var failed []BatchItem[T]
+	for _, item := range r.Items {
+		if item.Err != nil {
+			failed = append(failed, item)
+		}
+	}
+	return failed
+}
+
+// UnmarshalJSON decodes each entry either as a T or, when it carries an
+// "error" object, as a BatchItemError.
+func (r *BatchResource[T]) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Create []json.RawMessage `json:"create"`
+		Update []json.RawMessage `json:"update"`
+		Delete []json.RawMessage `json:"delete"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	r.Items = nil
+	var err error
+	if r.Create, err = r.decodeItems(BatchCreate, raw.Create); err != nil {
+		return err
+	}
+	if r.Update, err = r.decodeItems(BatchUpdate, raw.Update); err != nil {
+		return err
+	}
+	r.Delete, err = r.decodeItems(BatchDelete, raw.Delete)
+	return err
+}
+
+func (r *BatchResource[T]) decodeItems(op BatchOp, entries []json.RawMessage) ([]*T, error) {
+	if entries == nil {
+		return nil, nil
+	}
+	items := make([]*T, len(entries))
+	for i, entry := range entries {
+		var probe struct {
+			ID    StringInt `json:"id"`
+			Error *struct {
+				Code    string                 `json:"code"`
+				Message string                 `json:"message"`
+				Data    map[string]interface{} `json:"data"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(entry, &probe); err == nil && probe.Error != nil {
+			itemErr := &BatchItemError{
+				ID:      int64(probe.ID),
+				Code:    probe.Error.Code,
+				Message: probe.Error.Message,
+				Data:    probe.Error.Data,
+			}
+			if status, ok := probe.Error.Data["status"].(float64); ok {
+				itemErr.Status = int(status)
+			}
+			r.Items = append(r.Items, BatchItem[T]{Op: op, Index: i, Err: itemErr})
+			continue
+		}
+		item := new(T)
+		if err := json.Unmarshal(entry, item); err != nil {
+			return nil, err
+		}
+		items[i] = item
+		r.Items = append(r.Items, BatchItem[T]{Op: op, Index: i, Item: item})
+	}
+	return items, nil
+}
+
+// Len returns the number of objects in the batch.
+func (o BatchOption[T]) Len() int {
+	return len(o.Create) + len(o.Update) + len(o.Delete)
+}
+
+// batchChunk is a part of a BatchOption and where it starts in each list.
+type batchChunk[T any] struct {
+	option                       BatchOption[T]
+	createAt, updateAt, deleteAt int
+}
+
+// chunks splits o into batches of at most size objects, creates first, then
+// updates and deletes, keeping the input order.
+func (o BatchOption[T]) chunks(size int) []batchChunk[T] {
+	var chunks []batchChunk[T]
+	var cur batchChunk[T]
+	n := 0
+	flush := func() {
+		if n > 0 {
+			chunks = append(chunks, cur)
+		}
+		n = 0
+	}
+	for i := range o.Create {
+		if n == size {
+			flush()
+		}
+		if n == 0 {
+			cur = batchChunk[T]{createAt: i}
+		}
+		cur.option.Create = append(cur.option.Create, o.Create[i])
+		n++
+	}
+	for i := range o.Update {
+		if n == size {
+			flush()
+		}
+		if n == 0 {
+			cur = batchChunk[T]{createAt: len(o.Create), updateAt: i}
+		}
+		cur.option.Update = append(cur.option.Update, o.Update[i])
+		n++
+	}
+	for i := range o.Delete {
+		if n == size {
+			flush()
+		}
+		if n == 0 {
+			cur = batchChunk[T]{createAt: len(o.Create), updateAt: len(o.Update), deleteAt: i}
+		}
+		cur.option.Delete = append(cur.option.Delete, o.Delete[i])
+		n++
+	}
+	flush()
+	return chunks
+}
+
+func (c batchChunk[T]) offset(op BatchOp) int {
+	switch op {
+	case BatchCreate:
+		return c.createAt
+	case BatchUpdate:
+		return c.updateAt
+	}
+	return c.deleteAt
+}
+
+// failedItems reports every object of the chunk as failed with err.
+func (c batchChunk[T]) failedItems(err error) []BatchItem[T] {
+	var items []BatchItem[T]
+	add := func(op BatchOp, n int) {
+		for i := range n {
+			items = append(items, BatchItem[T]{Op: op, Index: c.offset(op) + i, Err: err})
+		}
+	}
+	add(BatchCreate, len(c.option.Create))
+	add(BatchUpdate, len(c.option.Update))
+	add(BatchDelete, len(c.option.Delete))
+	return items
+}
+
+// BatchFunc sends a single batch request, such as the BatchWithContext
+// method of the services.
+type BatchFunc[T any] func(ctx context.Context, option BatchOption[T]) (*BatchResource[T], error)
+
+// BatchConfig controls how RunBatch splits and sends a batch.
+type BatchConfig struct {
+	// Size is the maximum number of objects per request, MaxBatchSize if zero.
+	Size int
+	// Concurrency is the number of requests sent at once, 1 if zero.
+	Concurrency int
+}
+
+// BatchChunkError is the error of one request of a split batch, with the
+// range of the input objects it carried.
+type BatchChunkError struct {
+	Chunk int
+	// Create, Update and Delete are the [start, end) ranges of the chunk in
+	// the corresponding lists of the BatchOption.
+	Create, Update, Delete [2]int
+	Err                    error
+}
+
+func (e *BatchChunkError) Error() string {
+	return fmt.Sprintf("batch chunk %d (create %v, update %v, delete %v): %v", e.Chunk, e.Create, e.Update, e.Delete, e.Err)
+}
+
+func (e *BatchChunkError) Unwrap() error { return e.Err }
+
+// BatchError is returned by RunBatch when some chunks failed. The results of
+// the other chunks are still returned.
+type BatchError struct {
+	Chunks []*BatchChunkError
+}
+
+func (e *BatchError) Error() string {
+	msgs := make([]string, len(e.Chunks))
+	for i, c := range e.Chunks {
+		msgs[i] = c.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Chunks))
+	for i, c := range e.Chunks {
+		errs[i] = c
+	}
+	return errs
+}
+
+// RunBatch sends option with fn, split into requests that WooCommerce
+// accepts. The results are merged in input order: Create[i] of the result
+// answers Create[i] of option, and so on, and is nil when its object or its
+// chunk failed, as told by Items. If any chunk failed, the error is a
+// *BatchError.
+//
+//	res, err := woocommerce.RunBatch(ctx, client.Product.BatchWithContext, option, woocommerce.BatchConfig{Concurrency: 4})
+func RunBatch[T any](ctx context.Context, fn BatchFunc[T], option BatchOption[T], config BatchConfig) (*BatchResource[T], error) {
+	size := config.Size
+	if size <= 0 || size > MaxBatchSize {
+		size = MaxBatchSize
+	}
+	chunks := option.chunks(size)
+	result := &BatchResource[T]{
+		Create: make([]*T, len(option.Create)),
+		Update: make([]*T, len(option.Update)),
+		Delete: make([]*T, len(option.Delete)),
+	}
+	if len(chunks) == 0 {
+		return result, nil
+	}
+
+	calls := newCallGroup(ctx)
+	defer calls.done()
+	errs := make([]*BatchChunkError, len(chunks))
+	results := make([]*BatchResource[T], len(chunks))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(max(config.Concurrency, 1), len(chunks)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				chunk := chunks[i]
+				res, err := fn(calls.context(ctx), chunk.option)
+				if err != nil {
+					errs[i] = &BatchChunkError{
+						Chunk:  i,
+						Create: [2]int{chunk.createAt, chunk.createAt + len(chunk.option.Create)},
+						Update: [2]int{chunk.updateAt, chunk.updateAt + len(chunk.option.Update)},
+						Delete: [2]int{chunk.deleteAt, chunk.deleteAt + len(chunk.option.Delete)},
+						Err:    err,
+					}
+					continue
+				}
+				// Each chunk writes to its own ranges, so no locking is needed.
+				results[i] = res
+				copy(result.Create[chunk.createAt:chunk.createAt+len(chunk.option.Create)], res.Create)
+				copy(result.Update[chunk.updateAt:chunk.updateAt+len(chunk.option.Update)], res.Update)
+				copy(result.Delete[chunk.deleteAt:chunk.deleteAt+len(chunk.option.Delete)], res.Delete)
+			}
+		}()
+	}
+	for i := range chunks {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var failed []*BatchChunkError
+	for i, chunk := range chunks {
+		if errs[i] != nil {
+			failed = append(failed, errs[i])
+			result.Items = append(result.Items, chunk.failedItems(errs[i])...)
+			continue
+		}
+		for _, item := range results[i].Items {
+			item.Index += chunk.offset(item.Op)
+			result.Items = append(result.Items, item)
+		}
+	}
+	if len(failed) > 0 {
+		return result, &BatchError{Chunks: failed}
+	}
+	return result, nil
+}
+
+// postBatch posts option to path, split into several requests when it holds
+// more than MaxBatchSize objects.
+func postBatch[T any](ctx context.Context, c *Client, path string, option BatchOption[T]) (*BatchResource[T], error) {
+	if option.Len() <= MaxBatchSize {
+		resource := new(BatchResource[T])
+		if err := c.PostWithContext(ctx, path, option, resource); err != nil {
+			return nil, err
+		}
+		return resource, nil
+	}
+	send := func(ctx context.Context, option BatchOption[T]) (*BatchResource[T], error) {
+		return postBatch(ctx, c, path, option)
+	}
+	return RunBatch(ctx, send, option, BatchConfig{})
+}
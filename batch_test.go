@@ -0,0 +1,152 @@
+package woocommerce
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// echoBatch answers a batch request with its own objects, deletes as IDs.
+func echoBatch(t *testing.T, sizes *[]int, mu *sync.Mutex) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in BatchOption[Order]
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		*sizes = append(*sizes, in.Len())
+		mu.Unlock()
+		out := BatchResource[Order]{}
+		for _, o := range in.Create {
+			out.Create = append(out.Create, &Order{ID: o.ID})
+		}
+		for _, o := range in.Update {
+			out.Update = append(out.Update, &Order{ID: o.ID})
+		}
+		for _, id := range in.Delete {
+			out.Delete = append(out.Delete, &Order{ID: id})
+		}
+		json.NewEncoder(w).Encode(out)
+	})
+}
+
+func testBatchOption(creates, updates, deletes int) OrderBatchOption {
+	var option OrderBatchOption
+	for i := 0; i < creates; i++ {
+		option.Create = append(option.Create, Order{ID: int64(1000 + i)})
+	}
+	for i := 0; i < updates; i++ {
+		option.Update = append(option.Update, Order{ID: int64(2000 + i)})
+	}
+	for i := 0; i < deletes; i++ {
+		option.Delete = append(option.Delete, int64(3000+i))
+	}
+	return option
+}
+
+func TestOrderBatch_Chunks(t *testing.T) {
+	var mu sync.Mutex
+	var sizes []int
+	c := newTestClient(t, echoBatch(t, &sizes, &mu))
+
+	res, err := c.Order.Batch(testBatchOption(150, 30, 25))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sizes) != 3 || sizes[0] != 100 || sizes[1] != 100 || sizes[2] != 5 {
+		t.Errorf("chunk sizes %v", sizes)
+	}
+	if len(res.Create) != 150 || res.Create[149].ID != 1149 || res.Update[29].ID != 2029 || res.Delete[0].ID != 3000 {
+		t.Errorf("results not merged in order")
+	}
+}
+
+func TestRunBatch_PartialFailure(t *testing.T) {
+	var mu sync.Mutex
+	var sizes []int
+	c := newTestClient(t, echoBatch(t, &sizes, &mu))
+
+	fail := errors.New("boom")
+	send := func(ctx context.Context, option OrderBatchOption) (*OrderBatchResource, error) {
+		if len(option.Create) > 0 && option.Create[0].ID == 1010 {
+			return nil, fail
+		}
+		return c.Order.BatchWithContext(ctx, option)
+	}
+	res, err := RunBatch(context.Background(), send, testBatchOption(25, 5, 0), BatchConfig{Size: 10, Concurrency: 3})
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Chunks) != 1 || !errors.Is(err, fail) {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if chunk := batchErr.Chunks[0]; chunk.Chunk != 1 || chunk.Create != [2]int{10, 20} {
+		t.Errorf("wrong failed chunk %+v", chunk)
+	}
+	if res.Create[9].ID != 1009 || res.Create[10] != nil || res.Create[20].ID != 1020 || res.Update[4].ID != 2004 {
+		t.Errorf("successful chunks lost")
+	}
+	if len(sizes) != 2 {
+		t.Errorf("sent %d chunks, want 2", len(sizes))
+	}
+}
+
+func TestBatchResource_ItemErrors(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"create": [{"id": 10}, {"id": 0, "error": {"code": "woocommerce_rest_invalid_product_id", "message": "Invalid product.", "data": {"status": 400}}}],
+			"delete": [{"id": 99, "error": {"code": "woocommerce_rest_shop_order_invalid_id", "message": "Invalid ID.", "data": {"status": 404}}}]
+		}`))
+	}))
+
+	res, err := c.Order.Batch(testBatchOption(2, 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Create[0].ID != 10 || res.Create[1] != nil || res.Delete[0] != nil {
+		t.Errorf("failed items decoded as objects: %+v", res)
+	}
+	failed := res.Failed()
+	if len(failed) != 2 || len(res.Items) != 3 {
+		t.Fatalf("items %+v", res.Items)
+	}
+	var itemErr *BatchItemError
+	if failed[0].Op != BatchCreate || failed[0].Index != 1 || !errors.As(failed[0].Err, &itemErr) || itemErr.Status != 400 {
+		t.Errorf("create failure %+v", failed[0])
+	}
+	if !errors.As(failed[1].Err, &itemErr) || failed[1].Op != BatchDelete || itemErr.ID != 99 || itemErr.Code != "woocommerce_rest_shop_order_invalid_id" {
+		t.Errorf("delete failure %+v", failed[1])
+	}
+}
+
+func TestRunBatch_ItemIndexes(t *testing.T) {
+	var mu sync.Mutex
+	var sizes []int
+	c := newTestClient(t, echoBatch(t, &sizes, &mu))
+
+	fail := errors.New("boom")
+	send := func(ctx context.Context, option OrderBatchOption) (*OrderBatchResource, error) {
+		if len(option.Delete) > 0 {
+			return nil, fail
+		}
+		return c.Order.BatchWithContext(ctx, option)
+	}
+	res, _ := RunBatch(context.Background(), send, testBatchOption(15, 0, 3), BatchConfig{Size: 10})
+	if len(res.Items) != 18 {
+		t.Fatalf("%d items, want 18", len(res.Items))
+	}
+	for i, item := range res.Items[:10] {
+		if item.Op != BatchCreate || item.Index != i || item.Item == nil || item.Item.ID != int64(1000+i) {
+			t.Errorf("item %d: %+v", i, item)
+		}
+	}
+	// The second chunk carried creates 10-14 and the deletes.
+	if item := res.Items[10]; item.Op != BatchCreate || item.Index != 10 || !errors.Is(item.Err, fail) {
+		t.Errorf("item 10: %+v", item)
+	}
+	if last := res.Items[17]; last.Op != BatchDelete || last.Index != 2 || !errors.Is(last.Err, fail) {
+		t.Errorf("last item %+v", last)
+	}
+}
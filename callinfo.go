@@ -0,0 +1,90 @@
+package woocommerce
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CallInfo describes how a single API call went.
+type CallInfo struct {
+	// Attempts is the number of HTTP requests sent, including retries.
+	Attempts int
+	// Duration is the total latency of the call, including waits between retries.
+	Duration time.Duration
+	// StatusCode is the status of the last response, or 0 if none was received.
+	StatusCode int
+	// Drift lists the schema drift of the response in DecodeDiagnostic and
+	// DecodeStrict modes, or is nil if it has none.
+	Drift *DriftReport
+}
+
+type callInfoKey struct{}
+
+// ContextWithCallInfo returns a context that makes the next API call made
+// with it fill info once it completes. Use a separate CallInfo per call; the
+// same info must not be shared by calls running concurrently. Operations
+// made of several requests, such as RunBatch and Pager.FetchAll, fill info
+// once with all of them: the attempts summed, the duration of the whole
+// operation, the highest status and the drift of every response.
+//
+//	var info woocommerce.CallInfo
+//	order, err := client.Order.GetWithContext(woocommerce.ContextWithCallInfo(ctx, &info), id, nil)
+//	log.Printf("took %s in %d attempts", info.Duration, info.Attempts)
+func ContextWithCallInfo(ctx context.Context, info *CallInfo) context.Context {
+	return context.WithValue(ctx, callInfoKey{}, info)
+}
+
+func recordCallInfo(ctx context.Context, call CallInfo) {
+	if info, ok := ctx.Value(callInfoKey{}).(*CallInfo); ok && info != nil {
+		*info = call
+	}
+}
+
+// callGroup gives each request of an operation made of several, possibly
+// concurrent, requests a CallInfo of its own, and fills the caller's with
+// all of them once the operation is done.
+type callGroup struct {
+	parent *CallInfo
+	start  time.Time
+	mu     sync.Mutex
+	calls  []*CallInfo
+}
+
+func newCallGroup(ctx context.Context) *callGroup {
+	parent, _ := ctx.Value(callInfoKey{}).(*CallInfo)
+	return &callGroup{parent: parent, start: time.Now()}
+}
+
+// context returns ctx with a CallInfo of its own for one request.
+func (g *callGroup) context(ctx context.Context) context.Context {
+	if g.parent == nil {
+		return ctx
+	}
+	info := new(CallInfo)
+	g.mu.Lock()
+	g.calls = append(g.calls, info)
+	g.mu.Unlock()
+	return context.WithValue(ctx, callInfoKey{}, info)
+}
+
+// done fills the caller's CallInfo once every request has completed.
+func (g *callGroup) done() {
+	if g.parent == nil {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	merged := CallInfo{Duration: time.Since(g.start)}
+	for _, call := range g.calls {
+		merged.Attempts += call.Attempts
+		merged.StatusCode = max(merged.StatusCode, call.StatusCode)
+		if call.Drift.Len() > 0 {
+			if merged.Drift == nil {
+				merged.Drift = new(DriftReport)
+			}
+			merged.Drift.merge(call.Drift)
+		}
+	}
+	*g.parent = merged
+}
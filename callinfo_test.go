@@ -0,0 +1,99 @@
+package woocommerce
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_ConcurrentCallInfo(t *testing.T) {
+	// Orders with an odd ID fail once with 503 before succeeding.
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		n, _ := strconv.Atoi(id)
+		mu.Lock()
+		first := !seen[id]
+		seen[id] = true
+		mu.Unlock()
+		if n%2 == 1 && first {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintf(w, `{"id":%s}`, id)
+	})
+	c := newTestClient(t, h, WithRetryPolicy(testRetryPolicy(&fakeClock{now: time.Unix(0, 0)})))
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 50; i++ {
+		wg.Add(1)
+		go func(id int64) {
+			defer wg.Done()
+			var info CallInfo
+			order, err := c.Order.GetWithContext(ContextWithCallInfo(context.Background(), &info), id, nil)
+			if err != nil {
+				t.Errorf("order %d: %v", id, err)
+				return
+			}
+			want := 1 + int(id%2)
+			if order.ID != id || info.Attempts != want || info.StatusCode != http.StatusOK {
+				t.Errorf("order %d: got id %d, %d attempts, status %d; want %d attempts", id, order.ID, info.Attempts, info.StatusCode, want)
+			}
+		}(int64(i))
+	}
+	wg.Wait()
+}
+
+func TestContextWithCallInfo_Error(t *testing.T) {
+	h, _ := statusHandler(http.StatusNotFound)
+	c := newTestClient(t, h)
+
+	var info CallInfo
+	if _, err := c.Order.GetWithContext(ContextWithCallInfo(context.Background(), &info), 1, nil); err == nil {
+		t.Fatal("expected error")
+	}
+	if info.Attempts != 1 || info.StatusCode != http.StatusNotFound || info.Duration <= 0 {
+		t.Errorf("unexpected call info %+v", info)
+	}
+}
+
+func TestContextWithCallInfo_SplitOperations(t *testing.T) {
+	var queries []url.Values
+	pages := pagedOrders(&queries)
+	var mu sync.Mutex
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if strings.HasSuffix(r.URL.Path, "/batch") {
+			fmt.Fprint(w, `{"create":[{"id":1}]}`)
+			return
+		}
+		pages.ServeHTTP(w, r)
+	}))
+
+	var info CallInfo
+	ctx := ContextWithCallInfo(context.Background(), &info)
+	option := OrderBatchOption{Create: []Order{{}, {}, {}}}
+	if _, err := RunBatch(ctx, c.Order.BatchWithContext, option, BatchConfig{Size: 1, Concurrency: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if info.Attempts != 3 || info.StatusCode != http.StatusOK || info.Duration <= 0 {
+		t.Errorf("batch call info %+v, want 3 attempts", info)
+	}
+
+	info = CallInfo{}
+	pager := NewPager(ctx, c.Order.ListWithPaginationWithContext, OrderListOption{})
+	if _, err := pager.FetchAll(4, func(o Order) int64 { return o.ID }); err != nil {
+		t.Fatal(err)
+	}
+	if info.Attempts != len(queries) || info.StatusCode != http.StatusOK {
+		t.Errorf("FetchAll call info %+v, want %d attempts", info, len(queries))
+	}
+}
@@ -1,7 +1,7 @@
 package woocommerce
 
 import (
-	"fmt"
+	"context"
 )
 
 const (
@@ -15,14 +15,25 @@ type CouponService interface {
 	Get(couponID int64, options interface{}) (*Coupon, error)
 	List(options interface{}) ([]Coupon, error)
 	Update(coupon *Coupon) (*Coupon, error)
+	Patch(coupon *Coupon, fields ...string) (*Coupon, error)
 	Delete(couponID int64, options interface{}) (*Coupon, error)
 	Batch(option CouponBatchOption) (*CouponBatchResource, error)
 	ListWithPagination(options interface{}) ([]Coupon, *Pagination, error)
+	CreateWithContext(ctx context.Context, coupon Coupon) (*Coupon, error)
+	GetWithContext(ctx context.Context, couponID int64, options interface{}) (*Coupon, error)
+	ListWithContext(ctx context.Context, options interface{}) ([]Coupon, error)
+	UpdateWithContext(ctx context.Context, coupon *Coupon) (*Coupon, error)
+	PatchWithContext(ctx context.Context, coupon *Coupon, fields ...string) (*Coupon, error)
+	DeleteWithContext(ctx context.Context, couponID int64, options interface{}) (*Coupon, error)
+	BatchWithContext(ctx context.Context, option CouponBatchOption) (*CouponBatchResource, error)
+	ListWithPaginationWithContext(ctx context.Context, options interface{}) ([]Coupon, *Pagination, error)
+	Pager(ctx context.Context, options CouponListOption) *Pager[Coupon]
+	Base() *Resource[Coupon, CouponListOption]
 }
 
 // CouponServiceOp handles communication with the coupon related methods of WooCommerce'API
 type CouponServiceOp struct {
-	client *Client
+	*Resource[Coupon, CouponListOption]
 }
 
 // CouponListOption list all the coupon list option request params
@@ -38,7 +49,7 @@ type Coupon struct {
 	ID                        int64      `json:"id,omitempty"`
 	Code                      string     `json:"code,omitempty"`
 	Slug                      string     `json:"slug"`
-	Amount                    string     `json:"amount,omitempty"`
+	Amount                    Money      `json:"amount,omitzero"`
 	DateCreated               StringTime `json:"date_created,omitempty"`
 	DateCreatedGmt            StringTime `json:"date_created_gmt,omitempty"`
 	DateModified              StringTime `json:"date_modified,omitempty"`
@@ -58,78 +69,32 @@ type Coupon struct {
 	ProductCategories         []int      `json:"product_categories,omitempty"`
 	ExcludedProductCategories []int      `json:"excluded_product_categories,omitempty"`
 	ExcludeSaleItems          bool       `json:"exclude_sale_items,omitempty"`
-	MinimumAmount             string     `json:"minimum_amount,omitempty"`
-	MaximumAmount             string     `json:"maximum_amount,omitempty"`
-	NominalAmount             float64    `json:"nominal_amount,omitempty"`
+	MinimumAmount             Money      `json:"minimum_amount,omitzero"`
+	MaximumAmount             Money      `json:"maximum_amount,omitzero"`
+	NominalAmount             Money      `json:"nominal_amount,omitzero"`
 	EmailRestrictions         []string   `json:"email_restrictions,omitempty"`
 	UsedBy                    []string   `json:"used_by,omitempty"`
 	MetaData                  []MetaData `json:"meta_data,omitempty"`
 	Status                    string     `json:"status,omitempty"`
 	Links                     Links      `json:"_links"`
-}
-
-type CouponBatchOption struct {
-	Create []Coupon `json:"create,omitempty"`
-	Update []Coupon `json:"update,omitempty"`
-	Delete []int64  `json:"delete,omitempty"`
-}
+	Embedded                  Embedded   `json:"_embedded,omitempty"`
 
-type CouponBatchResource struct {
-	Create []*Coupon `json:"create,omitempty"`
-	Update []*Coupon `json:"update,omitempty"`
-	Delete []*Coupon `json:"delete,omitempty"`
+	// Extra keeps the fields not modelled above, such as those of plugins.
+	Extra Extra `json:"-"`
 }
 
-func (c *CouponServiceOp) List(options interface{}) ([]Coupon, error) {
-	coupons, _, err := c.ListWithPagination(options)
-	return coupons, err
+// UnmarshalJSON decodes c, keeping the fields it does not model in Extra.
+func (c *Coupon) UnmarshalJSON(data []byte) error {
+	type plain Coupon
+	return unmarshalExtra(data, (*plain)(c), &c.Extra)
 }
 
-func (c *CouponServiceOp) ListWithPagination(options interface{}) ([]Coupon, *Pagination, error) {
-	path := couponsBasePath
-	resource := make([]Coupon, 0)
-	headers, err := c.client.createAndDoGetHeaders("GET", path, nil, options, &resource)
-	if err != nil {
-		return nil, nil, err
-	}
-	pagination, err := extractPagination(headers)
-	if err != nil {
-		return nil, nil, err
-	}
-	return resource, pagination, err
+// MarshalJSON encodes c along with its Extra fields.
+func (c Coupon) MarshalJSON() ([]byte, error) {
+	type plain Coupon
+	return marshalExtra(plain(c), c.Extra)
 }
 
-func (c *CouponServiceOp) Create(coupon Coupon) (*Coupon, error) {
-	path := couponsBasePath
-	resource := new(Coupon)
-	err := c.client.Post(path, coupon, &resource)
-	return resource, err
-}
-
-func (c *CouponServiceOp) Get(couponID int64, options interface{}) (*Coupon, error) {
-	path := fmt.Sprintf("%s/%d", couponsBasePath, couponID)
-	resource := new(Coupon)
-	err := c.client.Get(path, resource, options)
-	return resource, err
-}
+type CouponBatchOption = BatchOption[Coupon]
 
-func (c *CouponServiceOp) Update(coupon *Coupon) (*Coupon, error) {
-	path := fmt.Sprintf("%s/%d", couponsBasePath, coupon.ID)
-	resource := new(Coupon)
-	err := c.client.Put(path, coupon, &resource)
-	return resource, err
-}
-
-func (c *CouponServiceOp) Delete(couponID int64, options interface{}) (*Coupon, error) {
-	path := fmt.Sprintf("%s/%d", couponsBasePath, couponID)
-	resource := new(Coupon)
-	err := c.client.Delete(path, options, &resource)
-	return resource, err
-}
-
-func (c *CouponServiceOp) Batch(data CouponBatchOption) (*CouponBatchResource, error) {
-	path := fmt.Sprintf("%s/batch", couponsBasePath)
-	resource := new(CouponBatchResource)
-	err := c.client.Post(path, data, &resource)
-	return resource, err
-}
+type CouponBatchResource = BatchResource[Coupon]
@@ -28,7 +28,7 @@ func TestCouponServiceOp_Create(t *testing.T) {
 	coupon := Coupon{
 		Code:          "TEST" + time.Now().Format("20060102150405"),
 		DiscountType:  "fixed_cart",
-		Amount:        "10.00",
+		Amount:        MustParseMoney("10.00"),
 		Description:   "Test coupon",
 		UsageCount:    0,
 		FreeShipping:  false,
@@ -70,7 +70,7 @@ func TestCouponServiceOp_Delete(t *testing.T) {
 	coupon := Coupon{
 		Code:         "DELETE" + time.Now().Format("20060102150405"),
 		DiscountType: "fixed_cart",
-		Amount:       "5.00",
+		Amount:       MustParseMoney("5.00"),
 		Description:  "Test coupon to delete",
 	}
 	created, err := client.Coupon.Create(coupon)
@@ -95,13 +95,13 @@ func TestCouponServiceOp_Batch(t *testing.T) {
 			{
 				Code:         "BATCH1" + timeNow,
 				DiscountType: "fixed_cart",
-				Amount:       "10.00",
+				Amount:       MustParseMoney("10.00"),
 				Description:  "Batch coupon 1",
 			},
 			{
 				Code:         "BATCH2" + timeNow,
 				DiscountType: "percent",
-				Amount:       "15",
+				Amount:       MustParseMoney("15"),
 				Description:  "Batch coupon 2",
 			},
 		},
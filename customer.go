@@ -1,28 +1,39 @@
 package woocommerce
 
 import (
-	"fmt"
+	"context"
 )
 
 const (
-  customersBasePath = "customers"
+	customersBasePath = "customers"
 )
 
 // CustomerService is an interface for interfacing with the customers endpoints of woocommerce API
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#customers
 type CustomerService interface {
-  Create(customer Customer) (*Customer, error)
-  Get(customerId int64, options interface{}) (*Customer, error)
-  List(options interface{}) ([]Customer, error)
-  ListWithPagination(options interface{}) ([]Customer, *Pagination, error)
-  Update(customer *Customer) (*Customer, error)
-  Delete(customerID int64, options interface{}) (*Customer, error)
-  Batch(option CustomerBatchOption) (*CustomerBatchResource, error)
+	Create(customer Customer) (*Customer, error)
+	Get(customerId int64, options interface{}) (*Customer, error)
+	List(options interface{}) ([]Customer, error)
+	ListWithPagination(options interface{}) ([]Customer, *Pagination, error)
+	Update(customer *Customer) (*Customer, error)
+	Patch(customer *Customer, fields ...string) (*Customer, error)
+	Delete(customerID int64, options interface{}) (*Customer, error)
+	Batch(option CustomerBatchOption) (*CustomerBatchResource, error)
+	CreateWithContext(ctx context.Context, customer Customer) (*Customer, error)
+	GetWithContext(ctx context.Context, customerId int64, options interface{}) (*Customer, error)
+	ListWithContext(ctx context.Context, options interface{}) ([]Customer, error)
+	ListWithPaginationWithContext(ctx context.Context, options interface{}) ([]Customer, *Pagination, error)
+	UpdateWithContext(ctx context.Context, customer *Customer) (*Customer, error)
+	PatchWithContext(ctx context.Context, customer *Customer, fields ...string) (*Customer, error)
+	DeleteWithContext(ctx context.Context, customerID int64, options interface{}) (*Customer, error)
+	BatchWithContext(ctx context.Context, option CustomerBatchOption) (*CustomerBatchResource, error)
+	Pager(ctx context.Context, options CustomerListOption) *Pager[Customer]
+	Base() *Resource[Customer, CustomerListOption]
 }
 
 // CustomerServiceOp handles communication with the customer related methods of WooCommerce'API
 type CustomerServiceOp struct {
-  client *Client
+	*Resource[Customer, CustomerListOption]
 }
 
 // CustomerListOption list all thee customer list option request params
@@ -47,125 +58,78 @@ type CustomerServiceOp struct {
 // product  integer  Limit result set to customers assigned a specific product.
 // dp  integer  Number of decimal points to use in each resource. Default is 2.
 type CustomerListOption struct {
-  ListOptions
-  Parent        []int64  `url:"parent,omitempty"`
-  ParentExclude []int64  `url:"parent_exclude,omitempty"`
-  Status        []string `url:"status,omitempty"`
-  Dp            int      `url:"id,omitempty"`
+	ListOptions
+	Parent        []int64  `url:"parent,omitempty"`
+	ParentExclude []int64  `url:"parent_exclude,omitempty"`
+	Status        []string `url:"status,omitempty"`
+	Dp            int      `url:"dp,omitempty"`
 }
 
 // CustomerBatchOption setting  operate for customer in batch way
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#batch-update-customers
-type CustomerBatchOption struct {
-  Create []Customer `json:"create,omitempty"`
-  Update []Customer `json:"update,omitempty"`
-  Delete []int64    `json:"delete,omitempty"`
-}
+type CustomerBatchOption = BatchOption[Customer]
 
 // CustomerBatchResource conservation the response struct for CustomerBatchOption request
-type CustomerBatchResource struct {
-  Create []*Customer `json:"create,omitempty"`
-  Update []*Customer `json:"update,omitempty"`
-  Delete []*Customer `json:"delete,omitempty"`
-}
+type CustomerBatchResource = BatchResource[Customer]
 
 type CustomerLastOrder struct {
-  ID   int64  `json:"id,omitempty"`
-  Date string `json:"date,omitempty"`
+	ID   int64  `json:"id,omitempty"`
+	Date string `json:"date,omitempty"`
 }
 
 // Customer represents a WooCommerce Customer
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#customer-properties
 type Customer struct {
-  ID                int64                  `json:"id,omitempty"`
-  AvatarURL         string                 `json:"avatar_url,omitempty"`
-  Capabilities      map[string]interface{} `json:"capabilities,omitempty"`
-  DateCreated       StringTime             `json:"date_created,omitempty"`
-  DateCreatedGmt    StringTime             `json:"date_created_gmt,omitempty"`
-  DateModified      StringTime             `json:"date_modified,omitempty"`
-  DateModifiedGmt   StringTime             `json:"date_modified_gmt,omitempty"`
-  LastOrder         CustomerLastOrder      `json:"last_order,omitempty"`
-  OrdersCount       uint64                 `json:"orders_count,omitempty"`
-  TotalSpent        *StringFloat           `json:"total_spent,omitempty"`
-  Description       string                 `json:"description,omitempty"`
-  Email             string                 `json:"email,omitempty"`
-  ExtraCapabilities map[string]interface{} `json:"extra_capabilities,omitempty"`
-  FirstName         string                 `json:"first_name,omitempty"`
-  IsPayingCustomer  bool                   `json:"is_paying_customer,omitempty"`
-  LastName          string                 `json:"last_name,omitempty"`
-  Link              string                 `json:"link,omitempty"`
-  Name              string                 `json:"name,omitempty"`
-  Nickname          string                 `json:"nickname,omitempty"`
-  RegisteredDate    string                 `json:"registered_date,omitempty"`
-  Role              string                 `json:"role,omitempty"`
-  Slug              string                 `json:"slug,omitempty"`
-  URL               string                 `json:"url,omitempty"`
-  Username          string                 `json:"username,omitempty"`
-  Password          string                 `json:"password,omitempty"`
-  Billing           *Billing               `json:"billing,omitempty"`
-  Shipping          *Shipping              `json:"shipping,omitempty"`
-  CartHash          string                 `json:"cart_hash,omitempty"`
-	Meta        []MetaData  `json:"meta,omitempty"`
-	MetaData    []MetaData  `json:"meta_data,omitempty"`
-  Links             Links                  `json:"_links"`
+	ID                int64                  `json:"id,omitempty"`
+	AvatarURL         string                 `json:"avatar_url,omitempty"`
+	Capabilities      map[string]interface{} `json:"capabilities,omitempty"`
+	DateCreated       StringTime             `json:"date_created,omitempty"`
+	DateCreatedGmt    StringTime             `json:"date_created_gmt,omitempty"`
+	DateModified      StringTime             `json:"date_modified,omitempty"`
+	DateModifiedGmt   StringTime             `json:"date_modified_gmt,omitempty"`
+	LastOrder         CustomerLastOrder      `json:"last_order,omitempty"`
+	OrdersCount       uint64                 `json:"orders_count,omitempty"`
+	TotalSpent        *Money                 `json:"total_spent,omitempty"`
+	Description       string                 `json:"description,omitempty"`
+	Email             string                 `json:"email,omitempty"`
+	ExtraCapabilities map[string]interface{} `json:"extra_capabilities,omitempty"`
+	FirstName         string                 `json:"first_name,omitempty"`
+	IsPayingCustomer  bool                   `json:"is_paying_customer,omitempty"`
+	LastName          string                 `json:"last_name,omitempty"`
+	Link              string                 `json:"link,omitempty"`
+	Name              string                 `json:"name,omitempty"`
+	Nickname          string                 `json:"nickname,omitempty"`
+	RegisteredDate    string                 `json:"registered_date,omitempty"`
+	Role              string                 `json:"role,omitempty"`
+	Slug              string                 `json:"slug,omitempty"`
+	URL               string                 `json:"url,omitempty"`
+	Username          string                 `json:"username,omitempty"`
+	Password          string                 `json:"password,omitempty"`
+	Billing           *Billing               `json:"billing,omitempty"`
+	Shipping          *Shipping              `json:"shipping,omitempty"`
+	CartHash          string                 `json:"cart_hash,omitempty"`
+	Meta              []MetaData             `json:"meta,omitempty"`
+	MetaData          []MetaData             `json:"meta_data,omitempty"`
+	Links             Links                  `json:"_links"`
+	Embedded          Embedded               `json:"_embedded,omitempty"`
+
+	// Extra keeps the fields not modelled above, and Extensions those
+	// registered with RegisterExtension.
+	Extra      Extra      `json:"-"`
+	Extensions Extensions `json:"-"`
 }
 
-func (o *CustomerServiceOp) List(options interface{}) ([]Customer, error) {
-  customers, _, err := o.ListWithPagination(options)
-  return customers, err
+// UnmarshalJSON decodes c, keeping the fields it does not model in Extra
+// and Extensions.
+func (c *Customer) UnmarshalJSON(data []byte) error {
+	type plain Customer
+	return unmarshalExtensible[Customer](data, (*plain)(c), &c.Extra, &c.Extensions)
 }
 
-// ListWithPagination lists products and return pagination to retrieve next/previous results.
-func (o *CustomerServiceOp) ListWithPagination(options interface{}) ([]Customer, *Pagination, error) {
-  path := customersBasePath
-  resource := make([]Customer, 0)
-  // headers := http.Header{}
-  headers, err := o.client.createAndDoGetHeaders("GET", path, nil, options, &resource)
-  if err != nil {
-    return nil, nil, err
-  }
-  // Extract pagination info from header
-  pagination, err := extractPagination(headers)
-  if err != nil {
-    return nil, nil, err
-  }
-
-  return resource, pagination, err
+// MarshalJSON encodes c along with its Extra and Extensions fields.
+func (c Customer) MarshalJSON() ([]byte, error) {
+	type plain Customer
+	return marshalExtensible(plain(c), c.Extra, c.Extensions)
 }
 
-func (o *CustomerServiceOp) Create(customer Customer) (*Customer, error) {
-  path := customersBasePath
-  resource := new(Customer)
-
-  err := o.client.Post(path, customer, &resource)
-  return resource, err
-}
-
-// Get individual customer
-func (o *CustomerServiceOp) Get(customerID int64, options interface{}) (*Customer, error) {
-  path := fmt.Sprintf("%s/%d", customersBasePath, customerID)
-  resource := new(Customer)
-  err := o.client.Get(path, resource, options)
-  return resource, err
-}
-
-func (o *CustomerServiceOp) Update(customer *Customer) (*Customer, error) {
-  path := fmt.Sprintf("%s/%d", customersBasePath, customer.ID)
-  resource := new(Customer)
-  err := o.client.Put(path, customer, &resource)
-  return resource, err
-}
-
-func (o *CustomerServiceOp) Delete(customerID int64, options interface{}) (*Customer, error) {
-  path := fmt.Sprintf("%s/%d", customersBasePath, customerID)
-  resource := new(Customer)
-  err := o.client.Delete(path, options, &resource)
-  return resource, err
-}
-
-func (o *CustomerServiceOp) Batch(data CustomerBatchOption) (*CustomerBatchResource, error) {
-  path := fmt.Sprintf("%s/batch", customersBasePath)
-  resource := new(CustomerBatchResource)
-  err := o.client.Post(path, data, &resource)
-  return resource, err
-}
+func (c *Customer) extensions() *Extensions { return &c.Extensions }
@@ -14,7 +14,6 @@ func TestCustomerServiceOp_List(t *testing.T) {
 			Page:    1,
 			PerPage: 10,
 		},
-		Role: "customer",
 	}
 	customers, err := client.Customer.List(options)
 	if err != nil {
@@ -32,13 +31,13 @@ func TestCustomerServiceOp_Create(t *testing.T) {
 		LastName:  "Customer",
 		Username:  "testuser-" + time.Now().Format("20060102150405"),
 		Role:      "customer",
-		Billing: CustomerAddress{
+		Billing: &Billing{
 			FirstName: "Test",
 			LastName:  "Customer",
 			Address1:  "123 Test Street",
 			City:      "Test City",
 			State:     "TS",
-			Postcode:  "12345",
+			PostCode:  "12345",
 			Country:   "US",
 			Phone:     "555-1234",
 		},
@@ -128,15 +127,3 @@ func TestCustomerServiceOp_Batch(t *testing.T) {
 		}
 	}
 }
-
-func TestCustomerServiceOp_GetDownloads(t *testing.T) {
-	downloads, err := client.Customer.GetDownloads(1, nil)
-	if err != nil {
-		t.Logf("get customer downloads error: %v", err)
-	} else {
-		t.Logf("got %d downloads for customer 1", len(downloads))
-		for _, dl := range downloads {
-			t.Logf("download: id=%d, name=%s", dl.ID, dl.Name)
-		}
-	}
-}
@@ -1,18 +1,20 @@
 package woocommerce
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
 )
 
 const (
-	filesBasePath = "download"
+	filesBasePath    = "download"
+	fileMetaBasePath = "download-meta"
 )
 
 // FileService is an interface for interfacing with the file endpoints of
@@ -22,6 +24,9 @@ type FileService interface {
 	Get(file string) (*File, error)
 	GetStream(file string) (*FileDownload, error)
 	GetMeta(file string) (*FileMeta, error)
+	GetWithContext(ctx context.Context, file string) (*File, error)
+	GetStreamWithContext(ctx context.Context, file string) (*FileDownload, error)
+	GetMetaWithContext(ctx context.Context, file string) (*FileMeta, error)
 }
 
 // FileMeta contains file metadata returned by the download-meta endpoint.
@@ -78,61 +83,87 @@ func (fd *FileDownload) Close() error {
 // Get retrieves a file using the JSON API. The entire response body is buffered
 // in memory. For large files, use GetStream instead.
 func (w *FileServiceOp) Get(file string) (*File, error) {
+	return w.GetWithContext(context.Background(), file)
+}
+
+// GetWithContext is like Get but bound to ctx.
+func (w *FileServiceOp) GetWithContext(ctx context.Context, file string) (*File, error) {
 	path := fmt.Sprintf("%s/%s", filesBasePath, file)
 	resource := new(File)
 	// Use createAndDoGetHeaders to access response headers
-	headers, err := w.Client.createAndDoGetHeaders("GET", path, nil, nil, &resource)
-
-	if err == nil {
-		w.Client.log.Infof("FileServiceOp.Get success: file=%s, size=%d, headers=%v", file, len(resource.Content), headers)
+	headers, err := w.Client.createAndDoGetHeaders(ctx, "GET", path, nil, nil, resource)
+	if err != nil {
+		return nil, err
 	}
 
-	return resource, err
+	log := logWith(w.Client.log, slog.String("file", file), slog.Int("size", len(resource.Content)))
+	log.Infof("FileServiceOp.Get success: file=%s, size=%d, headers=%v", file, len(resource.Content), w.Client.redaction.header(headers))
+
+	return resource, nil
 }
 
 // GetStream downloads a file by streaming the HTTP response body directly to a
 // temporary file on disk. This avoids buffering the entire file in memory.
 // Caller must call Close() on the returned FileDownload to clean up.
 func (w *FileServiceOp) GetStream(file string) (*FileDownload, error) {
+	return w.GetStreamWithContext(context.Background(), file)
+}
+
+// GetStreamWithContext is like GetStream but bound to ctx, which also covers
+// reading the response body. The request goes through the same rate limiting,
+// retries and instrumentation as the other calls, and fails with a
+// ResponseError when the store answers with an error.
+func (w *FileServiceOp) GetStreamWithContext(ctx context.Context, file string) (*FileDownload, error) {
 	relPath := fmt.Sprintf("%s/%s", filesBasePath, file)
 
-	req, err := w.Client.NewAPIRequest("GET", relPath, nil, nil)
+	req, err := w.Client.NewAPIRequestWithContext(ctx, "GET", relPath, nil, nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
 
-	w.Client.logRequest(req)
-
-	resp, err := w.Client.Client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("executing request: %w", err)
+	var download *FileDownload
+	var written int64
+	read := responseReader(func(body io.Reader) error {
+		download, written, err = readDownload(body, file)
+		return err
+	})
+	if _, err := w.Client.doGetHeaders(req, read); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		errBody := make([]byte, 1024)
-		n, _ := io.ReadFull(resp.Body, errBody)
-		return nil, fmt.Errorf("http %d downloading %s: %s", resp.StatusCode, file, string(errBody[:n]))
-	}
+	log := logWith(w.Client.log, slog.String("file", file), slog.Int64("size", written))
+	log.Infof("FileServiceOp.GetStream: file=%s, size=%d, tmp=%s", download.Name, written, download.tmpFile.Name())
 
-	// The download endpoint returns JSON: {"filename":"...", "content":"<base64>"}
-	// We stream-decode to avoid buffering the entire file in memory.
-	dec := json.NewDecoder(resp.Body)
+	return download, nil
+}
+
+// readDownload stream-decodes the JSON of the download endpoint,
+// {"filename":"...", "content":"<base64>"}, writing the content to a
+// temporary file without buffering it all in memory.
+func readDownload(body io.Reader, file string) (*FileDownload, int64, error) {
+	dec := json.NewDecoder(body)
 
 	// Expect opening {
 	if _, err := dec.Token(); err != nil {
-		return nil, fmt.Errorf("expected JSON object start: %w", err)
+		return nil, 0, fmt.Errorf("expected JSON object start: %w", err)
 	}
 
 	var filename string
 	var tmp *os.File
 	var written int64
+	fail := func(err error) (*FileDownload, int64, error) {
+		if tmp != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+		return nil, 0, err
+	}
 
 	for dec.More() {
 		// Read field name
 		tok, err := dec.Token()
 		if err != nil {
-			return nil, fmt.Errorf("reading JSON key: %w", err)
+			return fail(fmt.Errorf("reading JSON key: %w", err))
 		}
 		key, ok := tok.(string)
 		if !ok {
@@ -143,7 +174,7 @@ func (w *FileServiceOp) GetStream(file string) (*FileDownload, error) {
 		case "filename":
 			var name string
 			if err := dec.Decode(&name); err != nil {
-				return nil, fmt.Errorf("decoding filename: %w", err)
+				return fail(fmt.Errorf("decoding filename: %w", err))
 			}
 			filename = name
 
@@ -152,38 +183,38 @@ func (w *FileServiceOp) GetStream(file string) (*FileDownload, error) {
 			// Read the raw base64 string token, decode in chunks.
 			tok, err := dec.Token()
 			if err != nil {
-				return nil, fmt.Errorf("reading content token: %w", err)
+				return fail(fmt.Errorf("reading content token: %w", err))
 			}
 			b64str, ok := tok.(string)
 			if !ok {
-				return nil, fmt.Errorf("expected string for content, got %T", tok)
+				return fail(fmt.Errorf("expected string for content, got %T", tok))
 			}
 
-			tmp, err = os.CreateTemp("", "wc-dl-*")
-			if err != nil {
-				return nil, fmt.Errorf("creating temp file: %w", err)
+			if tmp == nil {
+				tmp, err = os.CreateTemp("", "wc-dl-*")
+				if err != nil {
+					return fail(fmt.Errorf("creating temp file: %w", err))
+				}
 			}
 
 			// Decode the base64 string in chunks to the temp file
 			b64Reader := base64.NewDecoder(base64.StdEncoding, strings.NewReader(b64str))
 			written, err = io.Copy(tmp, b64Reader)
 			if err != nil {
-				tmp.Close()
-				os.Remove(tmp.Name())
-				return nil, fmt.Errorf("decoding base64 content: %w", err)
+				return fail(fmt.Errorf("decoding base64 content: %w", err))
 			}
 
 		default:
 			// Skip unknown fields
 			var discard json.RawMessage
 			if err := dec.Decode(&discard); err != nil {
-				return nil, fmt.Errorf("skipping field %s: %w", key, err)
+				return fail(fmt.Errorf("skipping field %s: %w", key, err))
 			}
 		}
 	}
 
 	if tmp == nil {
-		return nil, fmt.Errorf("no content field found in download response for %s", file)
+		return nil, 0, fmt.Errorf("no content field found in download response for %s", file)
 	}
 
 	if filename == "" {
@@ -191,22 +222,23 @@ func (w *FileServiceOp) GetStream(file string) (*FileDownload, error) {
 	}
 
 	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
-		tmp.Close()
-		os.Remove(tmp.Name())
-		return nil, fmt.Errorf("seeking temp file: %w", err)
+		return fail(fmt.Errorf("seeking temp file: %w", err))
 	}
 
-	w.Client.log.Infof("FileServiceOp.GetStream: file=%s, size=%d, tmp=%s", filename, written, tmp.Name())
-
-	return &FileDownload{Name: filename, tmpFile: tmp}, nil
+	return &FileDownload{Name: filename, tmpFile: tmp}, written, nil
 }
 
 // GetMeta retrieves file metadata (size, filename, last_modified) without
 // downloading the file content. Uses the download-meta endpoint.
 func (w *FileServiceOp) GetMeta(file string) (*FileMeta, error) {
-	path := fmt.Sprintf("download-meta/%s", file)
+	return w.GetMetaWithContext(context.Background(), file)
+}
+
+// GetMetaWithContext is like GetMeta but bound to ctx.
+func (w *FileServiceOp) GetMetaWithContext(ctx context.Context, file string) (*FileMeta, error) {
+	path := fmt.Sprintf("%s/%s", fileMetaBasePath, file)
 	resource := new(FileMeta)
-	_, err := w.Client.createAndDoGetHeaders("GET", path, nil, nil, resource)
+	_, err := w.Client.createAndDoGetHeaders(ctx, "GET", path, nil, nil, resource)
 	if err != nil {
 		return nil, err
 	}
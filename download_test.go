@@ -0,0 +1,52 @@
+package woocommerce
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestFileServiceOp_GetStream(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		n := calls
+		mu.Unlock()
+		switch {
+		case r.URL.Path != "/wp-json/wc/v3/download/report.csv":
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"code":"woocommerce_rest_file_invalid","message":"Not found."}`)
+		case n == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			// "id,total\n" in base64
+			io.WriteString(w, `{"filename":"report.csv","size":9,"content":"aWQsdG90YWwK"}`)
+		}
+	}), WithRetryPolicy(testRetryPolicy(&fakeClock{})))
+	ctx := context.Background()
+
+	var info CallInfo
+	download, err := c.File.GetStreamWithContext(ContextWithCallInfo(ctx, &info), "report.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer download.Close()
+	content, _ := io.ReadAll(download)
+	if download.Name != "report.csv" || string(content) != "id,total\n" {
+		t.Errorf("downloaded %s: %q", download.Name, content)
+	}
+	if info.Attempts != 2 || info.StatusCode != http.StatusOK {
+		t.Errorf("CallInfo = %+v, want a retried call", info)
+	}
+
+	_, err = c.File.GetStreamWithContext(ctx, "missing.csv")
+	var respErr ResponseError
+	if !errors.Is(err, ErrNotFound) || !errors.As(err, &respErr) {
+		t.Errorf("err = %v, want a not found ResponseError", err)
+	}
+}
@@ -0,0 +1,396 @@
+package woocommerce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DecodeMode sets how closely responses are checked against the library's
+// types. See WithDecodeMode.
+type DecodeMode int
+
+const (
+	// DecodeLenient decodes responses as best it can and reports nothing.
+	DecodeLenient DecodeMode = iota
+	// DecodeDiagnostic also collects the schema drift of each response in a
+	// DriftReport, logged as a warning and set in the call's CallInfo.
+	DecodeDiagnostic
+	// DecodeStrict is like DecodeDiagnostic, but calls whose response has
+	// unknown fields or values that failed to parse fail with a *DriftError.
+	// Coercions are only reported.
+	DecodeStrict
+)
+
+// DriftKind is the kind of a DriftIssue.
+type DriftKind int
+
+const (
+	// DriftUnknownField is a field the type does not model, kept in Extra
+	// if it has one and dropped otherwise.
+	DriftUnknownField DriftKind = iota + 1
+	// DriftCoercion is a value of another JSON type or format than the
+	// canonical one, such as a number sent as a string, which was converted.
+	DriftCoercion
+	// DriftParseFailure is a value that could not be parsed and was decoded
+	// as the zero value.
+	DriftParseFailure
+)
+
+func (k DriftKind) String() string {
+	switch k {
+	case DriftUnknownField:
+		return "unknown field"
+	case DriftCoercion:
+		return "coercion"
+	case DriftParseFailure:
+		return "parse failure"
+	}
+	return "DriftKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// DriftIssue is a difference between a response and the type it was decoded
+// into.
+type DriftIssue struct {
+	Kind DriftKind
+	// Path is the JSON path of the value, with array indices left out, e.g.
+	// "line_items[].price".
+	Path string
+	// Type is the Go type the value was decoded into, empty for unknown
+	// fields.
+	Type string
+	// Value is the first value seen, truncated and masked with the client's
+	// Redaction, and Detail what was done.
+	Value  string
+	Detail string
+	// Count is the number of values at Path with this issue.
+	Count int
+}
+
+func (i DriftIssue) String() string {
+	s := fmt.Sprintf("%s %s", i.Kind, i.Path)
+	if i.Type != "" {
+		s += " (" + i.Type + ")"
+	}
+	s += ": " + i.Value
+	if i.Detail != "" {
+		s += ", " + i.Detail
+	}
+	if i.Count > 1 {
+		s += fmt.Sprintf(" (x%d)", i.Count)
+	}
+	return s
+}
+
+// DriftReport lists the schema drift of a response: the unknown fields,
+// coercions and parse failures found decoding it, which usually mean a
+// plugin or WooCommerce update changed a field.
+type DriftReport struct {
+	Issues []DriftIssue
+}
+
+// Len returns the number of issues in r.
+func (r *DriftReport) Len() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.Issues)
+}
+
+// Kind returns the issues of r of kind k.
+func (r *DriftReport) Kind(k DriftKind) []DriftIssue {
+	if r == nil {
+		return nil
+	}
+	var issues []DriftIssue
+	for _, issue := range r.Issues {
+		if issue.Kind == k {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+func (r *DriftReport) String() string {
+	if r.Len() == 0 {
+		return "no drift"
+	}
+	issues := make([]string, len(r.Issues))
+	for i, issue := range r.Issues {
+		issues[i] = issue.String()
+	}
+	return strings.Join(issues, "; ")
+}
+
+// merge adds the issues of other to r, counting those r already has.
+func (r *DriftReport) merge(other *DriftReport) {
+next:
+	for _, issue := range other.Issues {
+		for i := range r.Issues {
+			if r.Issues[i].Kind == issue.Kind && r.Issues[i].Path == issue.Path {
+				r.Issues[i].Count += issue.Count
+				continue next
+			}
+		}
+		r.Issues = append(r.Issues, issue)
+	}
+}
+
+// DriftError is returned in DecodeStrict mode for responses with unknown
+// fields or values that failed to parse.
+type DriftError struct {
+	Method string
+	Path   string
+	Report *DriftReport
+}
+
+func (e *DriftError) Error() string {
+	return fmt.Sprintf("woocommerce: %s %s: schema drift: %s", e.Method, e.Path, e.Report)
+}
+
+// strict reports whether r fails a DecodeStrict call.
+func (r *DriftReport) strict() bool {
+	return len(r.Kind(DriftUnknownField)) > 0 || len(r.Kind(DriftParseFailure)) > 0
+}
+
+type lenientDecodingKey struct{}
+
+// withLenientDecoding returns a copy of ctx whose calls skip drift checking,
+// for internal calls that read a few fields of a larger document.
+func withLenientDecoding(ctx context.Context) context.Context {
+	return context.WithValue(ctx, lenientDecodingKey{}, true)
+}
+
+// decodeWithDrift decodes body into v and returns the drift between them,
+// or nil if there is none, along with the body read. The values reported
+// are masked with r.
+func decodeWithDrift(body io.Reader, v interface{}, r *Redaction) (*DriftReport, []byte, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, data, err
+	}
+	d := driftChecker{seen: map[string]int{}, redaction: r}
+	d.walk("", data, reflect.TypeOf(v))
+	if len(d.report.Issues) == 0 {
+		return nil, data, nil
+	}
+	return &d.report, data, nil
+}
+
+var (
+	stringIntType   = reflect.TypeFor[StringInt]()
+	stringOrIntType = reflect.TypeFor[StringOrInt]()
+	stringFloatType = reflect.TypeFor[StringFloat]()
+	stringTimeType  = reflect.TypeFor[StringTime]()
+	personTypeType  = reflect.TypeFor[PersonType]()
+	rawMessageType  = reflect.TypeFor[json.RawMessage]()
+)
+
+// maxDriftValue bounds the length of DriftIssue.Value.
+const maxDriftValue = 64
+
+type driftChecker struct {
+	report    DriftReport
+	seen      map[string]int // kind and path -> index in report.Issues
+	redaction *Redaction
+}
+
+func (d *driftChecker) add(kind DriftKind, path string, t reflect.Type, raw []byte, detail string) {
+	key := strconv.Itoa(int(kind)) + path
+	if i, ok := d.seen[key]; ok {
+		d.report.Issues[i].Count++
+		return
+	}
+	value := d.mask(path, raw)
+	if len(value) > maxDriftValue {
+		value = value[:maxDriftValue] + "..."
+	}
+	typ := ""
+	if t != nil {
+		typ = t.String()
+	}
+	d.seen[key] = len(d.report.Issues)
+	d.report.Issues = append(d.report.Issues, DriftIssue{Kind: kind, Path: path, Type: typ, Value: value, Detail: detail, Count: 1})
+}
+
+// mask returns raw, the JSON at path, with what the redaction lists masked:
+// all of it if a field of path matches, else the matching fields within.
+func (d *driftChecker) mask(path string, raw []byte) string {
+	if d.redaction == nil {
+		return string(raw)
+	}
+	for _, field := range strings.Split(path, ".") {
+		if d.redaction.matchField(strings.TrimSuffix(field, "[]")) {
+			return redacted
+		}
+	}
+	return string(d.redaction.body(raw))
+}
+
+// walk checks raw, the JSON at path, against t, the type it was decoded into.
+func (d *driftChecker) walk(path string, raw []byte, t reflect.Type) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return
+	}
+	quoted := raw[0] == '"'
+	var s string
+	if quoted {
+		json.Unmarshal(raw, &s)
+	} else {
+		s = string(raw)
+	}
+
+	switch t {
+	case rawMessageType:
+		return
+	case stringIntType:
+		trimmed := strings.TrimSpace(s)
+		if _, err := strconv.Atoi(trimmed); err != nil && trimmed != "" {
+			d.add(DriftParseFailure, path, t, raw, "decoded as 0")
+		} else if quoted && trimmed != "" {
+			d.add(DriftCoercion, path, t, raw, "string to integer")
+		}
+		return
+	case stringOrIntType:
+		if !quoted {
+			d.add(DriftCoercion, path, t, raw, "number to string")
+		}
+		return
+	case stringFloatType:
+		if quoted && s != "" {
+			d.add(DriftCoercion, path, t, raw, "string to number")
+		}
+		return
+	case moneyType:
+		if !quoted {
+			d.add(DriftCoercion, path, t, raw, "number to amount")
+		}
+		return
+	case stringTimeType:
+		if s = strings.TrimSpace(s); s == "" {
+			return
+		}
+		if _, format, err := parseStringTime(s); err == nil && format != stringTimeFormats[0] && format != stringTimeFormats[1] {
+			d.add(DriftCoercion, path, t, raw, "parsed as "+format)
+		}
+		return
+	case personTypeType:
+		switch strings.Trim(s, `"`) {
+		case "", "F", "J", "1", "2":
+		default:
+			d.add(DriftParseFailure, path, t, raw, "decoded as unknown")
+		}
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		d.walk(path, raw, t.Elem())
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if json.Unmarshal(raw, &items) == nil {
+			for _, item := range items {
+				d.walk(path+"[]", item, t.Elem())
+			}
+		}
+	case reflect.Map:
+		var values map[string]json.RawMessage
+		if json.Unmarshal(raw, &values) == nil {
+			for _, key := range slices.Sorted(maps.Keys(values)) {
+				d.walk(joinPath(path, key), values[key], t.Elem())
+			}
+		}
+	case reflect.Struct:
+		d.walkStruct(path, raw, t)
+	}
+}
+
+func (d *driftChecker) walkStruct(path string, raw []byte, t reflect.Type) {
+	var values map[string]json.RawMessage
+	if json.Unmarshal(raw, &values) != nil {
+		return
+	}
+	fields := structFields(t)
+	extensionRegistry.RLock()
+	extensions := extensionRegistry.types[t]
+	extensionRegistry.RUnlock()
+	if batchItemError(values) && fields["error"] == nil {
+		return
+	}
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		value := values[key]
+		field := fields[strings.ToLower(key)]
+		if field == nil {
+			_, field = extensionField(extensions, key)
+		}
+		if field == nil {
+			d.add(DriftUnknownField, joinPath(path, key), nil, value, "")
+			continue
+		}
+		d.walk(joinPath(path, key), value, field)
+	}
+}
+
+// batchItemError reports whether values are those of an object that failed
+// in a batch, which has an error in place of the object's fields.
+func batchItemError(values map[string]json.RawMessage) bool {
+	err := bytes.TrimSpace(values["error"])
+	return len(err) > 0 && err[0] == '{' && len(values) <= 2
+}
+
+var structFieldsCache sync.Map // reflect.Type -> map[string]reflect.Type
+
+// structFields returns the types of the JSON fields of struct type t, by
+// lower cased name.
+func structFields(t reflect.Type) map[string]reflect.Type {
+	if cached, ok := structFieldsCache.Load(t); ok {
+		return cached.(map[string]reflect.Type)
+	}
+	fields := map[string]reflect.Type{}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+		if name == "" && f.Anonymous {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for name, typ := range structFields(ft) {
+					if _, ok := fields[name]; !ok {
+						fields[name] = typ
+					}
+				}
+				continue
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f.Type
+	}
+	structFieldsCache.Store(t, fields)
+	return fields
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
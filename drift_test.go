@@ -0,0 +1,124 @@
+package woocommerce
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const driftOrder = `{"id":5,"currency":"BRL","total":12.5,"date_created":"19/01/2026",
+	"renewal":"3","billing":{"persontype":"X","church_size":"many"},
+	"line_items":[{"id":1,"quantity":1,"new_field":1},{"id":2,"quantity":1,"new_field":2}],
+	"correios_tracking_code":"BR1","plugin_data":{"a":1}}`
+
+func TestDecodeWithDrift(t *testing.T) {
+	var o Order
+	report, _, err := decodeWithDrift(strings.NewReader(driftOrder), &o, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]DriftKind{}
+	for _, issue := range report.Issues {
+		got[issue.Path] = issue.Kind
+	}
+	want := map[string]DriftKind{
+		"total":                  DriftCoercion,
+		"date_created":           DriftCoercion,
+		"billing.persontype":     DriftParseFailure,
+		"billing.church_size":    DriftParseFailure,
+		"line_items[].new_field": DriftUnknownField,
+		"plugin_data":            DriftUnknownField,
+		"renewal":                DriftCoercion,
+	}
+	if len(got) != len(want) {
+		t.Errorf("report: %s", report)
+	}
+	for path, kind := range want {
+		if got[path] != kind {
+			t.Errorf("%s: got %v, want %v", path, got[path], kind)
+		}
+	}
+	for _, issue := range report.Kind(DriftUnknownField) {
+		if issue.Path == "line_items[].new_field" && issue.Count != 2 {
+			t.Errorf("new_field counted %d times", issue.Count)
+		}
+	}
+	if o.Total.String() != "12.5" || o.Renewal != 3 {
+		t.Errorf("order not decoded: %+v", o)
+	}
+
+	var clean Order
+	if report, _, err := decodeWithDrift(strings.NewReader(`{"id":1,"total":"1.00","date_created":"2026-01-19T10:00:00"}`), &clean, nil); err != nil || report != nil {
+		t.Errorf("clean order reported %s, %v", report, err)
+	}
+}
+
+func TestClient_DecodeMode(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/batch") {
+			fmt.Fprint(w, `{"update":[{"id":1,"error":{"code":"woocommerce_rest_shop_order_invalid_id","message":"Invalid ID.","data":{"status":400}}}]}`)
+			return
+		}
+		fmt.Fprint(w, driftOrder)
+	})
+	ctx := context.Background()
+
+	var out bytes.Buffer
+	logger := &LeveledLogger{Level: LevelWarn, stdoutOverride: &out, stderrOverride: &out}
+	c := newTestClient(t, handler, WithLog(logger), WithDecodeMode(DecodeDiagnostic))
+	var info CallInfo
+	order, err := c.Order.GetWithContext(ContextWithCallInfo(ctx, &info), 5, nil)
+	if err != nil || order.ID != 5 {
+		t.Fatalf("order %+v, %v", order, err)
+	}
+	if info.Drift.Len() != 7 {
+		t.Errorf("CallInfo.Drift = %s", info.Drift)
+	}
+	if !strings.Contains(out.String(), "schema drift decoding *woocommerce.Order") || !strings.Contains(out.String(), "billing.church_size") {
+		t.Errorf("drift not logged: %s", out.String())
+	}
+
+	info = CallInfo{}
+	if _, err := c.Order.BatchWithContext(ContextWithCallInfo(ctx, &info), OrderBatchOption{Update: []Order{{ID: 1}}}); err != nil {
+		t.Fatal(err)
+	}
+	if info.Drift != nil {
+		t.Errorf("batch item error reported as drift: %s", info.Drift)
+	}
+
+	strict := newTestClient(t, handler, WithDecodeMode(DecodeStrict))
+	_, err = strict.Order.GetWithContext(ctx, 5, nil)
+	var driftErr *DriftError
+	if !errors.As(err, &driftErr) || len(driftErr.Report.Kind(DriftUnknownField)) != 2 {
+		t.Errorf("strict mode error = %v", err)
+	}
+
+	lenient := newTestClient(t, handler)
+	info = CallInfo{}
+	if _, err := lenient.Order.GetWithContext(ContextWithCallInfo(ctx, &info), 5, nil); err != nil || info.Drift != nil {
+		t.Errorf("lenient mode: drift %s, %v", info.Drift, err)
+	}
+}
+
+func TestClient_DriftRedacted(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":5,"billing_cpf":"123.456.789-00","plugin_data":{"email":"ana@example.com","points":3}}`)
+	}), WithDecodeMode(DecodeStrict))
+	_, err := c.Order.GetWithContext(context.Background(), 5, nil)
+	var driftErr *DriftError
+	if !errors.As(err, &driftErr) {
+		t.Fatalf("err = %v", err)
+	}
+	for _, secret := range []string{"123.456.789-00", "ana@example.com"} {
+		if strings.Contains(err.Error(), secret) {
+			t.Errorf("%s not redacted: %v", secret, err)
+		}
+	}
+	if !strings.Contains(err.Error(), `"points":3`) {
+		t.Errorf("unmasked value lost: %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package woocommerce
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestClient_DryRun(t *testing.T) {
+	dry := new(DryRun)
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("%s %s reached the store", r.Method, r.URL.Path)
+		}
+		io.WriteString(w, `{"id":7,"name":"Mug"}`)
+	}), WithDryRun(dry))
+	ctx := context.Background()
+
+	product, err := c.Product.GetWithContext(ctx, 7, nil)
+	if err != nil || product.Name != "Mug" {
+		t.Fatalf("get = %+v, %v", product, err)
+	}
+
+	created, err := c.Product.CreateWithContext(ctx, Product{Name: "Cup"})
+	if err != nil || created.Name != "Cup" {
+		t.Errorf("create = %+v, %v", created, err)
+	}
+	product.Name = "Big mug"
+	updated, err := c.Product.UpdateWithContext(ctx, product)
+	if err != nil || updated.ID != 7 || updated.Name != "Big mug" {
+		t.Errorf("update = %+v, %v", updated, err)
+	}
+	deleted, err := c.Product.DeleteWithContext(ctx, 7, DeleteOption{Force: true})
+	if err != nil || deleted.ID != 7 {
+		t.Errorf("delete = %+v, %v", deleted, err)
+	}
+	batch, err := c.Product.BatchWithContext(ctx, BatchOption[Product]{
+		Create: []Product{{Name: "Plate"}},
+		Delete: []int64{8, 9},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batch.Create) != 1 || batch.Create[0].Name != "Plate" || len(batch.Delete) != 2 || batch.Delete[1].ID != 9 {
+		t.Errorf("batch = %+v", batch)
+	}
+
+	requests := dry.Requests()
+	want := []struct{ method, path, operation string }{
+		{http.MethodPost, "/wp-json/wc/v3/products", "create"},
+		{http.MethodPut, "/wp-json/wc/v3/products/7", "update"},
+		{http.MethodDelete, "/wp-json/wc/v3/products/7", "delete"},
+		{http.MethodPost, "/wp-json/wc/v3/products/batch", "batch"},
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("recorded %d requests, want %d", len(requests), len(want))
+	}
+	for i, w := range want {
+		r := requests[i]
+		if r.Method != w.method || r.Path != w.path || r.Endpoint.Operation != w.operation {
+			t.Errorf("request %d = %s %s (%s), want %s %s (%s)", i, r.Method, r.Path, r.Endpoint.Operation, w.method, w.path, w.operation)
+		}
+	}
+	var body BatchOption[Product]
+	if err := json.Unmarshal(requests[3].Body, &body); err != nil || len(body.Create) != 1 || body.Create[0].Name != "Plate" || len(body.Delete) != 2 {
+		t.Errorf("batch body %s", requests[3].Body)
+	}
+	if requests[2].Query != "force=true" || requests[2].Body != nil {
+		t.Errorf("delete recorded %q with body %s", requests[2].Query, requests[2].Body)
+	}
+
+	dry.Reset()
+	if n := len(dry.Requests()); n != 0 {
+		t.Errorf("%d requests after Reset", n)
+	}
+}
+
+func TestDryRunResponse(t *testing.T) {
+	for _, tt := range []struct {
+		r    DryRunRequest
+		want string
+	}{
+		{DryRunRequest{Method: http.MethodPost, Body: []byte(`{"name":"Cup"}`)}, `{"name":"Cup"}`},
+		{DryRunRequest{Method: http.MethodPost}, `{}`},
+		{DryRunRequest{Method: http.MethodDelete, Endpoint: Endpoint{ID: "12"}}, `{"id":12}`},
+		{DryRunRequest{Method: http.MethodDelete, Endpoint: Endpoint{ID: "bacs"}}, `{"id":"bacs"}`},
+		{DryRunRequest{Method: http.MethodDelete}, `{}`},
+		{DryRunRequest{Method: http.MethodPost, Endpoint: Endpoint{Operation: "batch"}, Body: []byte(`{"update":[{"id":1}],"delete":[2]}`)}, `{"update":[{"id":1}],"delete":[{"id":2}]}`},
+	} {
+		if got := string(dryRunResponse(tt.r)); got != tt.want {
+			t.Errorf("dryRunResponse(%s %s) = %s, want %s", tt.r.Method, tt.r.Body, got, tt.want)
+		}
+	}
+}
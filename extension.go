@@ -0,0 +1,180 @@
+package woocommerce
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Field names of the built in extensions, registered for the plugins of
+// Brazilian stores the library started with.
+const (
+	ExtPaghiper     = "wc_paghiper_data"       // Order, *WC_Paghiper_Data
+	ExtNFE          = "nfe"                    // Order, []NFE; Product, *ProductNFE
+	ExtPaypal       = "paypal"                 // Order, *PaypalData
+	ExtTrackingCode = "correios_tracking_code" // Order and Subscription, string
+	ExtChurchEmail  = "church_email"           // Billing, string
+	ExtChurchSize   = "church_size"            // Billing, StringInt
+)
+
+func init() {
+	RegisterExtension[Order, *WC_Paghiper_Data](ExtPaghiper)
+	RegisterExtension[Order, []NFE](ExtNFE)
+	RegisterExtension[Order, *PaypalData](ExtPaypal)
+	RegisterExtension[Order, string](ExtTrackingCode)
+	RegisterExtension[Subscription, string](ExtTrackingCode)
+	RegisterExtension[Product, *ProductNFE](ExtNFE)
+	RegisterExtension[Billing, string](ExtChurchEmail)
+	RegisterExtension[Billing, StringInt](ExtChurchSize)
+}
+
+// Extensible is implemented by the objects plugins commonly add fields to:
+// *Order, *Subscription, *Product, *Customer and *Billing.
+type Extensible interface {
+	extensions() *Extensions
+}
+
+// Extensions holds the decoded values of the registered extension fields of
+// an object, by JSON field name. Use Extension and SetExtension to access
+// them type safely.
+type Extensions map[string]interface{}
+
+var extensionRegistry = struct {
+	sync.RWMutex
+	types map[reflect.Type]map[string]reflect.Type // object type -> field -> E
+}{types: map[reflect.Type]map[string]reflect.Type{}}
+
+// RegisterExtension registers E as the type of the JSON field added by a
+// plugin to the objects of type T, one of Order, Subscription, Product,
+// Customer and Billing. The field is then decoded into the object's
+// Extensions rather than its Extra:
+//
+//	type Loyalty struct {
+//		Points int `json:"points"`
+//	}
+//
+//	func init() {
+//		woocommerce.RegisterExtension[woocommerce.Customer, Loyalty]("loyalty")
+//	}
+//
+//	loyalty, ok := woocommerce.Extension[Loyalty](customer, "loyalty")
+//
+// Registering a field again replaces its type, which lets applications
+// override the built in extensions. It panics if T already models the field.
+func RegisterExtension[T any, E any, PT interface {
+	*T
+	Extensible
+}](field string) {
+	t := reflect.TypeFor[T]()
+	if knownFields(t)[strings.ToLower(field)] {
+		panic(fmt.Sprintf("woocommerce: %s already has a %q field", t, field))
+	}
+	extensionRegistry.Lock()
+	defer extensionRegistry.Unlock()
+	if extensionRegistry.types[t] == nil {
+		extensionRegistry.types[t] = map[string]reflect.Type{}
+	}
+	extensionRegistry.types[t][field] = reflect.TypeFor[E]()
+}
+
+// Extension returns the value of the extension field of obj, and whether it
+// is set with type E.
+func Extension[E any](obj Extensible, field string) (E, bool) {
+	v, ok := (*obj.extensions())[field].(E)
+	return v, ok
+}
+
+// SetExtension sets the extension field of obj to v, which is sent when obj
+// is encoded.
+func SetExtension[E any](obj Extensible, field string, v E) {
+	ext := obj.extensions()
+	if *ext == nil {
+		*ext = Extensions{}
+	}
+	(*ext)[field] = v
+}
+
+// decodeExtensions moves the registered extension fields of T from extra to
+// ext, decoded into their registered types.
+func decodeExtensions[T any](extra *Extra, ext *Extensions) error {
+	*ext = nil
+	extensionRegistry.RLock()
+	types := extensionRegistry.types[reflect.TypeFor[T]()]
+	extensionRegistry.RUnlock()
+	for _, key := range slices.Sorted(maps.Keys(*extra)) {
+		field, typ := extensionField(types, key)
+		if typ == nil {
+			continue
+		}
+		raw := (*extra)[key]
+		v := reflect.New(typ)
+		if err := json.Unmarshal(raw, v.Interface()); err != nil {
+			return fmt.Errorf("woocommerce: decoding extension %q: %w", field, err)
+		}
+		if *ext == nil {
+			*ext = Extensions{}
+		}
+		(*ext)[field] = v.Elem().Interface()
+		delete(*extra, key)
+	}
+	if len(*extra) == 0 {
+		*extra = nil
+	}
+	return nil
+}
+
+// extensionField returns the field and type of the extension of types that
+// key names. Like the fields a type models, it is matched exactly or else
+// case insensitively.
+func extensionField(types map[string]reflect.Type, key string) (string, reflect.Type) {
+	if typ, ok := types[key]; ok {
+		return key, typ
+	}
+	for field, typ := range types {
+		if strings.EqualFold(field, key) {
+			return field, typ
+		}
+	}
+	return "", nil
+}
+
+// encodeExtensions returns extra along with the encoded fields of ext.
+func encodeExtensions(extra Extra, ext Extensions) (Extra, error) {
+	if len(ext) == 0 {
+		return extra, nil
+	}
+	merged := make(Extra, len(extra)+len(ext))
+	for field, raw := range extra {
+		merged[field] = raw
+	}
+	for field, v := range ext {
+		if err := merged.Set(field, v); err != nil {
+			return nil, fmt.Errorf("woocommerce: encoding extension %q: %w", field, err)
+		}
+	}
+	return merged, nil
+}
+
+// unmarshalExtensible decodes data into v like unmarshalExtra, then moves
+// the registered extensions of O, the object type v is a plain copy of, from
+// extra to ext.
+func unmarshalExtensible[O any, T any](data []byte, v *T, extra *Extra, ext *Extensions) error {
+	if err := unmarshalExtra(data, v, extra); err != nil {
+		return err
+	}
+	return decodeExtensions[O](extra, ext)
+}
+
+// marshalExtensible encodes v like marshalExtra, along with the fields of
+// ext.
+func marshalExtensible[T any](v T, extra Extra, ext Extensions) ([]byte, error) {
+	merged, err := encodeExtensions(extra, ext)
+	if err != nil {
+		return nil, err
+	}
+	return marshalExtra(v, merged)
+}
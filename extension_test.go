@@ -0,0 +1,92 @@
+package woocommerce
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testLoyalty struct {
+	Points int `json:"points"`
+}
+
+func TestExtension_BuiltIn(t *testing.T) {
+	in := `{"id":1,"correios_tracking_code":"BR123","nfe":[{"uuid":"u1","n_nfe":"42"}],
+		"wc_paghiper_data":{"barcode":"789"},"billing":{"email":"a@b.c","church_size":"30"},"other":true}`
+	var o Order
+	if err := json.Unmarshal([]byte(in), &o); err != nil {
+		t.Fatal(err)
+	}
+	if code, ok := Extension[string](&o, ExtTrackingCode); !ok || code != "BR123" {
+		t.Errorf("tracking code = %q, %v", code, ok)
+	}
+	if nfe, ok := Extension[[]NFE](&o, ExtNFE); !ok || len(nfe) != 1 || nfe[0].NNFE != 42 {
+		t.Errorf("nfe = %+v, %v", nfe, ok)
+	}
+	if ph, ok := Extension[*WC_Paghiper_Data](&o, ExtPaghiper); !ok || ph.Barcode != "789" {
+		t.Errorf("paghiper = %+v, %v", ph, ok)
+	}
+	if _, ok := Extension[*PaypalData](&o, ExtPaypal); ok {
+		t.Error("paypal set without the field")
+	}
+	if _, ok := Extension[int](&o, ExtTrackingCode); ok {
+		t.Error("extension returned with the wrong type")
+	}
+	if size, ok := Extension[StringInt](o.Billing, ExtChurchSize); !ok || size != 30 {
+		t.Errorf("church size = %v, %v", size, ok)
+	}
+	if len(o.Extra) != 1 || o.Extra["other"] == nil {
+		t.Errorf("Extra = %v, want the unregistered field only", o.Extra)
+	}
+
+	SetExtension(&o, ExtTrackingCode, "BR456")
+	out, err := json.Marshal(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"correios_tracking_code":"BR456"`, `"barcode":"789"`, `"church_size":30`, `"other":true`} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("%s missing from %s", want, out)
+		}
+	}
+}
+
+func TestRegisterExtension(t *testing.T) {
+	RegisterExtension[Customer, testLoyalty]("test_loyalty")
+	defer func() {
+		extensionRegistry.Lock()
+		delete(extensionRegistry.types[reflect.TypeFor[Customer]()], "test_loyalty")
+		extensionRegistry.Unlock()
+	}()
+
+	var c Customer
+	if err := json.Unmarshal([]byte(`{"id":3,"test_loyalty":{"points":120}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if l, ok := Extension[testLoyalty](&c, "test_loyalty"); !ok || l.Points != 120 {
+		t.Errorf("loyalty = %+v, %v", l, ok)
+	}
+	if c.Extra != nil {
+		t.Errorf("registered field left in Extra: %v", c.Extra)
+	}
+	if err := json.Unmarshal([]byte(`{"id":3,"Test_Loyalty":{"points":7}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if l, ok := Extension[testLoyalty](&c, "test_loyalty"); !ok || l.Points != 7 || c.Extra != nil {
+		t.Errorf("loyalty in another case = %+v, %v, Extra %v", l, ok, c.Extra)
+	}
+	if report, _, err := decodeWithDrift(strings.NewReader(`{"id":3,"Test_Loyalty":{"points":7}}`), &c, nil); err != nil || report != nil {
+		t.Errorf("registered field in another case reported %s, %v", report, err)
+	}
+	if err := json.Unmarshal([]byte(`{"test_loyalty":"many"}`), &c); err == nil {
+		t.Error("expected an error decoding a mistyped extension")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic registering a modelled field")
+		}
+	}()
+	RegisterExtension[Customer, string]("email")
+}
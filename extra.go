@@ -0,0 +1,109 @@
+package woocommerce
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Extra holds the JSON fields of an object that its struct does not model,
+// such as those added by plugins. They are kept when decoding and sent back
+// when encoding, so a Get, modify, Update cycle does not drop them.
+type Extra map[string]json.RawMessage
+
+// Decode decodes the field key into v.
+func (e Extra) Decode(key string, v interface{}) error {
+	raw, ok := e[key]
+	if !ok {
+		return fmt.Errorf("woocommerce: no extra field %q", key)
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// Set encodes v as the field key.
+func (e *Extra) Set(key string, v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if *e == nil {
+		*e = Extra{}
+	}
+	(*e)[key] = raw
+	return nil
+}
+
+// unmarshalExtra decodes data into v, a struct type without a custom
+// UnmarshalJSON, and stores the fields v does not model in extra.
+func unmarshalExtra[T any](data []byte, v *T, extra *Extra) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	known := knownFields(reflect.TypeFor[T]())
+	*extra = nil
+	for key, raw := range fields {
+		if known[strings.ToLower(key)] {
+			continue
+		}
+		if *extra == nil {
+			*extra = Extra{}
+		}
+		(*extra)[key] = raw
+	}
+	return nil
+}
+
+// marshalExtra encodes v, a struct type without a custom MarshalJSON, and
+// appends the fields of extra it does not model, sorted by key. Its *Gmt
+// dates are encoded in UTC.
+func marshalExtra[T any](v T, extra Extra) ([]byte, error) {
+	utcTimes(&v)
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+	known := knownFields(reflect.TypeFor[T]())
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	empty := len(data) == 2
+	for _, key := range slices.Sorted(maps.Keys(extra)) {
+		if known[strings.ToLower(key)] {
+			continue
+		}
+		if !empty {
+			buf.WriteByte(',')
+		}
+		empty = false
+		name, _ := json.Marshal(key)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(extra[key])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+var knownFieldsCache sync.Map // reflect.Type -> map[string]bool
+
+// knownFields returns the lower cased JSON names of the fields of t, as
+// encoding/json matches them case insensitively.
+func knownFields(t reflect.Type) map[string]bool {
+	if cached, ok := knownFieldsCache.Load(t); ok {
+		return cached.(map[string]bool)
+	}
+	known := map[string]bool{}
+	for _, name := range jsonFields(t) {
+		known[strings.ToLower(name)] = true
+	}
+	knownFieldsCache.Store(t, known)
+	return known
+}
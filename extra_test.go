@@ -0,0 +1,102 @@
+package woocommerce
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestExtra_RoundTrip(t *testing.T) {
+	in := `{"id":3,"name":"Mug","ID":4,"_wc_plugin_field":{"a":[1,2]},"brand":"acme"}`
+	var p Product
+	if err := json.Unmarshal([]byte(in), &p); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Extra) != 2 {
+		t.Fatalf("Extra = %v, want the two plugin fields only", p.Extra)
+	}
+	var brand string
+	if err := p.Extra.Decode("brand", &brand); err != nil || brand != "acme" {
+		t.Errorf("brand = %q, %v", brand, err)
+	}
+	if err := p.Extra.Decode("missing", &brand); err == nil {
+		t.Error("expected an error decoding a missing field")
+	}
+	if err := p.Extra.Set("brand", "other"); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(out, &fields); err != nil {
+		t.Fatalf("invalid JSON %s: %v", out, err)
+	}
+	if string(fields["brand"]) != `"other"` || string(fields["_wc_plugin_field"]) != `{"a":[1,2]}` {
+		t.Errorf("extra fields lost in %s", out)
+	}
+	if string(fields["id"]) != "4" || strings.Count(string(out), `"ID"`) != 0 {
+		t.Errorf("known field duplicated in %s", out)
+	}
+}
+
+func TestExtra_Empty(t *testing.T) {
+	var n OrderNote
+	if err := json.Unmarshal([]byte(`{}`), &n); err != nil || n.Extra != nil {
+		t.Fatalf("note %+v, %v", n, err)
+	}
+	n.Extra.Set("a", 1)
+	type bare struct{}
+	out, err := marshalExtra(bare{}, n.Extra)
+	if err != nil || string(out) != `{"a":1}` {
+		t.Errorf("marshalExtra = %s, %v", out, err)
+	}
+}
+
+func TestExtra_OrderRefund(t *testing.T) {
+	in := `{"id":9,"amount":"10.00","reason":"Damaged","line_items":[{"id":1}]}`
+	var r OrderRefund
+	if err := json.Unmarshal([]byte(in), &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.ID != 9 || r.Amount.String() != "10.00" || len(r.Extra) != 2 || string(r.Extra["reason"]) != `"Damaged"` {
+		t.Fatalf("refund %+v", r)
+	}
+	out, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), `"reason":"Damaged"`) || !strings.Contains(string(out), `"line_items":[{"id":1}]`) {
+		t.Errorf("extra fields lost in %s", out)
+	}
+}
+
+func TestExtra_Update(t *testing.T) {
+	var sent map[string]json.RawMessage
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &sent); err != nil {
+				t.Errorf("invalid body %s: %v", body, err)
+			}
+		}
+		io.WriteString(w, `{"id":8,"status":"processing","_paghiper_boleto":{"barcode":"123"}}`)
+	}))
+	ctx := context.Background()
+	order, err := c.Order.GetWithContext(ctx, 8, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	order.Status = "completed"
+	if _, err := c.Order.UpdateWithContext(ctx, order); err != nil {
+		t.Fatal(err)
+	}
+	if string(sent["_paghiper_boleto"]) != `{"barcode":"123"}` || string(sent["status"]) != `"completed"` {
+		t.Errorf("update sent %v", sent)
+	}
+}
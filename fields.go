@@ -0,0 +1,122 @@
+package woocommerce
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// GetOption holds the query parameters of a single object request. Fields
+// limits the response to the given top level fields, e.g. []string{"id",
+// "sku", "stock_quantity"}, and Embed includes the embeddable linked
+// resources in the object's Embedded.
+type GetOption struct {
+	Context string   `url:"context,omitempty"`
+	Fields  []string `url:"_fields,omitempty,comma"`
+	Embed   bool     `url:"_embed,omitempty"`
+}
+
+// firstGetOption returns the query options of the Get methods taking an
+// optional GetOption: the first one given, or nil.
+func firstGetOption(options []GetOption) interface{} {
+	if len(options) == 0 {
+		return nil
+	}
+	return options[0]
+}
+
+// Project returns a Resource decoding the objects of r into P, a caller
+// defined struct holding only the fields it needs. Its List and Get requests
+// ask WooCommerce for the JSON fields of P only, through _fields, so large
+// payloads such as products are neither transferred nor decoded in full:
+//
+//	type StockLevel struct {
+//		ID            int64  `json:"id"`
+//		SKU           string `json:"sku"`
+//		StockQuantity int    `json:"stock_quantity"`
+//	}
+//
+//	stock := woocommerce.Project[StockLevel](client.Product.Base())
+//	for level, err := range stock.Pager(ctx, woocommerce.ProductListOptions{}).All() {
+//		...
+//	}
+//
+// Fields set explicitly in the options of a call take precedence.
+func Project[P any, T any, ListOpt any](r *Resource[T, ListOpt]) *Resource[P, ListOpt] {
+	return &Resource[P, ListOpt]{
+		client: r.client,
+		path:   r.path,
+		id:     jsonID[P],
+		fields: jsonFields(reflect.TypeFor[P]()),
+	}
+}
+
+// withFields adds the resource's projection to options as _fields, unless
+// options already selects fields.
+func (r *Resource[T, ListOpt]) withFields(options interface{}) (interface{}, error) {
+	if len(r.fields) == 0 {
+		return options, nil
+	}
+	values, err := optionValues(options)
+	if err != nil {
+		return nil, err
+	}
+	if !values.Has("_fields") {
+		values.Set("_fields", strings.Join(r.fields, ","))
+	}
+	return values, nil
+}
+
+var jsonFieldsCache sync.Map // reflect.Type -> []string
+
+// jsonFields returns the JSON names of the fields of struct type t, those of
+// embedded structs included.
+func jsonFields(t reflect.Type) []string {
+	if cached, ok := jsonFieldsCache.Load(t); ok {
+		return cached.([]string)
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var fields []string
+	if t.Kind() == reflect.Struct {
+		for i := range t.NumField() {
+			f := t.Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "-" || (!f.IsExported() && !f.Anonymous) {
+				continue
+			}
+			if name == "" && f.Anonymous {
+				fields = append(fields, jsonFields(f.Type)...)
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			fields = append(fields, name)
+		}
+	}
+	jsonFieldsCache.Store(t, fields)
+	return fields
+}
+
+// jsonID returns the field of p tagged json:"id", or 0 when there is none.
+func jsonID[P any](p *P) int64 {
+	v := reflect.ValueOf(p).Elem()
+	if v.Kind() != reflect.Struct {
+		return 0
+	}
+	for i := range v.NumField() {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+		if name != "id" {
+			continue
+		}
+		switch f := v.Field(i); {
+		case f.CanInt():
+			return f.Int()
+		case f.CanUint():
+			return int64(f.Uint())
+		}
+	}
+	return 0
+}
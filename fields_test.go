@@ -0,0 +1,147 @@
+package woocommerce
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+type testStockLevel struct {
+	ID            int64  `json:"id"`
+	SKU           string `json:"sku,omitempty"`
+	StockQuantity int    `json:"stock_quantity"`
+}
+
+func TestJSONFields(t *testing.T) {
+	type base struct {
+		ID int64 `json:"id"`
+	}
+	type projection struct {
+		base
+		Name     string `json:"name,omitempty"`
+		Internal string `json:"-"`
+		Untagged string
+		hidden   string
+	}
+	got := jsonFields(reflect.TypeFor[projection]())
+	if want := []string{"id", "name", "Untagged"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("jsonFields = %v, want %v", got, want)
+	}
+}
+
+func TestProject(t *testing.T) {
+	var queries []url.Values
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		if r.URL.Path == "/wp-json/wc/v3/products/7" {
+			fmt.Fprint(w, `{"id":7,"sku":"A-7","stock_quantity":3}`)
+			return
+		}
+		fmt.Fprint(w, `[{"id":1,"sku":"A-1","stock_quantity":0},{"id":2,"sku":"A-2","stock_quantity":12}]`)
+	}))
+	stock := Project[testStockLevel](c.Product.Base())
+	ctx := context.Background()
+
+	opts := ProductListOptions{}
+	opts.PerPage = 50
+	levels, err := stock.ListWithContext(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(levels) != 2 || levels[1] != (testStockLevel{ID: 2, SKU: "A-2", StockQuantity: 12}) {
+		t.Errorf("levels = %+v", levels)
+	}
+	level, err := stock.GetWithContext(ctx, 7, nil)
+	if err != nil || level.StockQuantity != 3 {
+		t.Errorf("level = %+v, %v", level, err)
+	}
+	opts.Fields = []string{"id"}
+	if _, err := stock.ListWithContext(ctx, opts); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Product.GetWithContext(ctx, 7, GetOption{Fields: []string{"id", "sku"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"id,sku,stock_quantity", "id,sku,stock_quantity", "id", "id,sku"}
+	for i, q := range queries {
+		if got := q.Get("_fields"); got != want[i] {
+			t.Errorf("request %d: _fields = %q, want %q", i, got, want[i])
+		}
+	}
+	if queries[0].Get("per_page") != "50" {
+		t.Errorf("options lost: %v", queries[0])
+	}
+}
+
+func TestProject_Pager(t *testing.T) {
+	var queries []url.Values
+	c := newTestClient(t, pagedOrders(&queries))
+	type orderID struct {
+		ID int64 `json:"id"`
+	}
+	ids := Project[orderID](c.Order.Base())
+
+	opts := OrderListOption{}
+	opts.PerPage = 2
+	n := 0
+	for _, err := range ids.Pager(context.Background(), opts).All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		n++
+	}
+	if n != 5 || len(queries) != 3 {
+		t.Fatalf("%d orders in %d requests", n, len(queries))
+	}
+	for _, q := range queries {
+		if q.Get("_fields") != "id" {
+			t.Errorf("_fields lost in %v", q)
+		}
+	}
+}
+
+func TestJSONID(t *testing.T) {
+	if id := jsonID(&testStockLevel{ID: 9}); id != 9 {
+		t.Errorf("jsonID = %d", id)
+	}
+	if id := jsonID(&struct{ Name string }{}); id != 0 {
+		t.Errorf("jsonID = %d", id)
+	}
+}
+
+func TestGetOption_Fields(t *testing.T) {
+	paths := map[string]string{}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths[r.URL.Path] = r.URL.Query().Get("_fields")
+		fmt.Fprint(w, `{}`)
+	}))
+	ctx := context.Background()
+	opt := GetOption{Fields: []string{"id", "enabled"}}
+
+	if _, err := c.PaymentGateway.GetWithContext(ctx, "bacs", opt); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.OrderNote.GetWithContext(ctx, 4, 9, opt); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"/wp-json/wc/v3/payment_gateways/bacs", "/wp-json/wc/v3/orders/4/notes/9"} {
+		if fields, ok := paths[path]; !ok || fields != "id,enabled" {
+			t.Errorf("%s requested with _fields=%q", path, fields)
+		}
+	}
+	if _, err := c.PaymentGateway.Get("cod"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.OrderNote.Get(4, 10); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"/wp-json/wc/v3/payment_gateways/cod", "/wp-json/wc/v3/orders/4/notes/10"} {
+		if fields, ok := paths[path]; !ok || fields != "" {
+			t.Errorf("%s requested with _fields=%q", path, fields)
+		}
+	}
+}
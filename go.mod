@@ -1,5 +1,5 @@
 module github.com/eideroliveira/woocommerce
 
-go 1.23
+go 1.24
 
 require github.com/google/go-querystring v1.0.0
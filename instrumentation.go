@@ -0,0 +1,235 @@
+package woocommerce
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Instrumentation receives measurements of every API call made by a client,
+// see WithInstrumentation option. Implementations must be safe for concurrent
+// use. Embed NopInstrumentation to implement only some of the methods.
+//
+// StartCall and EndCall bracket a call, retries included, so they can be
+// bridged to a tracer such as OpenTelemetry: StartCall starts a span and
+// stores it in the returned context, which is used for the HTTP requests, and
+// EndCall ends the span found in that context.
+type Instrumentation interface {
+	// StartCall is called before the first attempt of a call.
+	StartCall(ctx context.Context, e Endpoint) context.Context
+	// Attempt is called after each HTTP attempt. status is 0 when no
+	// response was received, in which case err is set.
+	Attempt(ctx context.Context, e Endpoint, attempt, status int, d time.Duration, err error)
+	// RateLimitWait is called when the client's rate limiter delayed a request by d.
+	RateLimitWait(ctx context.Context, e Endpoint, d time.Duration)
+	// EndCall is called once the call is over, err being the error returned to the caller.
+	EndCall(ctx context.Context, e Endpoint, info CallInfo, err error)
+}
+
+// NopInstrumentation is the default Instrumentation, which does nothing.
+type NopInstrumentation struct{}
+
+func (NopInstrumentation) StartCall(ctx context.Context, _ Endpoint) context.Context { return ctx }
+
+func (NopInstrumentation) Attempt(context.Context, Endpoint, int, int, time.Duration, error) {}
+
+func (NopInstrumentation) RateLimitWait(context.Context, Endpoint, time.Duration) {}
+
+func (NopInstrumentation) EndCall(context.Context, Endpoint, CallInfo, error) {}
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the latency
+// histogram kept by Metrics.
+var DefaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Metrics is an Instrumentation keeping per endpoint counters and latency
+// histograms in memory. It serves them in the Prometheus text format, so it
+// can be mounted as a /metrics handler.
+type Metrics struct {
+	buckets []float64
+
+	mu        sync.Mutex
+	endpoints map[string]*endpointMetrics
+}
+
+type endpointMetrics struct {
+	calls        int64
+	errors       map[string]int64 // by status, "network" for transport errors
+	retries      int64
+	waits        int64
+	waitSeconds  float64
+	bucketCounts []int64
+	latencySum   float64
+}
+
+// NewMetrics returns an empty Metrics with the given latency buckets, in
+// seconds, or DefaultLatencyBuckets if none are given.
+func NewMetrics(buckets ...float64) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &Metrics{buckets: buckets, endpoints: map[string]*endpointMetrics{}}
+}
+
+func (m *Metrics) endpoint(e Endpoint) *endpointMetrics {
+	name := e.String()
+	em, ok := m.endpoints[name]
+	if !ok {
+		em = &endpointMetrics{errors: map[string]int64{}, bucketCounts: make([]int64, len(m.buckets))}
+		m.endpoints[name] = em
+	}
+	return em
+}
+
+func (m *Metrics) StartCall(ctx context.Context, _ Endpoint) context.Context { return ctx }
+
+func (m *Metrics) Attempt(_ context.Context, e Endpoint, attempt, _ int, _ time.Duration, _ error) {
+	if attempt <= 1 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.endpoint(e).retries++
+}
+
+func (m *Metrics) RateLimitWait(_ context.Context, e Endpoint, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	em := m.endpoint(e)
+	em.waits++
+	em.waitSeconds += d.Seconds()
+}
+
+func (m *Metrics) EndCall(_ context.Context, e Endpoint, info CallInfo, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	em := m.endpoint(e)
+	em.calls++
+	if err != nil {
+		status := "network"
+		if info.StatusCode != 0 {
+			status = strconv.Itoa(info.StatusCode)
+		}
+		em.errors[status]++
+	}
+	secs := info.Duration.Seconds()
+	em.latencySum += secs
+	for i, le := range m.buckets {
+		if secs <= le {
+			em.bucketCounts[i]++
+		}
+	}
+}
+
+// snapshot returns a copy of the counters of every endpoint, so they can be
+// written out without holding m.mu.
+func (m *Metrics) snapshot() map[string]*endpointMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	endpoints := make(map[string]*endpointMetrics, len(m.endpoints))
+	for name, em := range m.endpoints {
+		c := *em
+		c.errors = make(map[string]int64, len(em.errors))
+		for s, n := range em.errors {
+			c.errors[s] = n
+		}
+		c.bucketCounts = append([]int64(nil), em.bucketCounts...)
+		endpoints[name] = &c
+	}
+	return endpoints
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	endpoints := m.snapshot()
+	names := make([]string, 0, len(endpoints))
+	for name := range endpoints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+	family := func(name, typ, help string, sample func(endpoint string, em *endpointMetrics)) {
+		fmt.Fprintf(cw, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+		for _, n := range names {
+			sample(strconv.Quote(n), endpoints[n])
+		}
+	}
+
+	family("woocommerce_requests_total", "counter", "API calls made, retries excluded.", func(ep string, em *endpointMetrics) {
+		fmt.Fprintf(cw, "woocommerce_requests_total{endpoint=%s} %d\n", ep, em.calls)
+	})
+	family("woocommerce_errors_total", "counter", "API calls that failed, by final status.", func(ep string, em *endpointMetrics) {
+		statuses := make([]string, 0, len(em.errors))
+		for s := range em.errors {
+			statuses = append(statuses, s)
+		}
+		sort.Strings(statuses)
+		for _, s := range statuses {
+			fmt.Fprintf(cw, "woocommerce_errors_total{endpoint=%s,status=%q} %d\n", ep, s, em.errors[s])
+		}
+	})
+	family("woocommerce_retries_total", "counter", "HTTP attempts beyond the first one of each call.", func(ep string, em *endpointMetrics) {
+		fmt.Fprintf(cw, "woocommerce_retries_total{endpoint=%s} %d\n", ep, em.retries)
+	})
+	family("woocommerce_rate_limit_waits_total", "counter", "Requests delayed by the rate limiter.", func(ep string, em *endpointMetrics) {
+		fmt.Fprintf(cw, "woocommerce_rate_limit_waits_total{endpoint=%s} %d\n", ep, em.waits)
+	})
+	family("woocommerce_rate_limit_wait_seconds_total", "counter", "Time spent waiting for the rate limiter.", func(ep string, em *endpointMetrics) {
+		fmt.Fprintf(cw, "woocommerce_rate_limit_wait_seconds_total{endpoint=%s} %s\n", ep, formatFloat(em.waitSeconds))
+	})
+	family("woocommerce_request_duration_seconds", "histogram", "Latency of API calls, retries included.", func(ep string, em *endpointMetrics) {
+		for i, le := range m.buckets {
+			fmt.Fprintf(cw, "woocommerce_request_duration_seconds_bucket{endpoint=%s,le=%q} %d\n", ep, formatFloat(le), em.bucketCounts[i])
+		}
+		fmt.Fprintf(cw, "woocommerce_request_duration_seconds_bucket{endpoint=%s,le=\"+Inf\"} %d\n", ep, em.calls)
+		fmt.Fprintf(cw, "woocommerce_request_duration_seconds_sum{endpoint=%s} %s\n", ep, formatFloat(em.latencySum))
+		fmt.Fprintf(cw, "woocommerce_request_duration_seconds_count{endpoint=%s} %d\n", ep, em.calls)
+	})
+
+	if err := cw.w.Flush(); err != nil && cw.err == nil {
+		cw.err = err
+	}
+	return cw.n, cw.err
+}
+
+// ServeHTTP serves the metrics in the Prometheus text format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// String returns the metrics in the Prometheus text format.
+func (m *Metrics) String() string {
+	var b strings.Builder
+	m.WriteTo(&b)
+	return b.String()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
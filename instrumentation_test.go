@@ -0,0 +1,113 @@
+package woocommerce
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetrics(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	h, _ := statusHandler(http.StatusServiceUnavailable, http.StatusOK, http.StatusNotFound)
+	limiter := NewRateLimiter(1, 1)
+	limiter.clock = clock
+	m := NewMetrics(0.5, 60)
+	c := newTestClient(t, h, WithRetryPolicy(testRetryPolicy(clock)), WithRateLimiter(limiter), WithInstrumentation(m))
+
+	if _, err := c.Order.Get(1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Order.Delete(1, DeleteOption{}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	got := m.String()
+	for _, want := range []string{
+		`woocommerce_requests_total{endpoint="orders.get"} 1`,
+		`woocommerce_requests_total{endpoint="orders.delete"} 1`,
+		`woocommerce_errors_total{endpoint="orders.delete",status="404"} 1`,
+		`woocommerce_retries_total{endpoint="orders.get"} 1`,
+		`woocommerce_rate_limit_waits_total{endpoint="orders.delete"} 1`,
+		`woocommerce_rate_limit_wait_seconds_total{endpoint="orders.delete"} 1`,
+		`woocommerce_request_duration_seconds_bucket{endpoint="orders.get",le="60"} 1`,
+		`woocommerce_request_duration_seconds_bucket{endpoint="orders.get",le="+Inf"} 1`,
+		`# TYPE woocommerce_request_duration_seconds histogram`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %s in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, `endpoint="orders.get",status`) {
+		t.Errorf("successful call counted as error:\n%s", got)
+	}
+}
+
+// slowWriter records a call on m for every write, as a slow /metrics client
+// would while other goroutines keep using the client.
+type slowWriter struct {
+	m *Metrics
+}
+
+func (w slowWriter) Write(p []byte) (int, error) {
+	w.m.EndCall(context.Background(), Endpoint{Service: "orders", Operation: "list"}, CallInfo{}, nil)
+	return len(p), nil
+}
+
+func TestMetrics_WriteToDoesNotBlockCalls(t *testing.T) {
+	m := NewMetrics()
+	m.EndCall(context.Background(), Endpoint{Service: "orders", Operation: "get"}, CallInfo{}, nil)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.WriteTo(slowWriter{m})
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("WriteTo holds the lock while writing")
+	}
+	if got := m.String(); !strings.Contains(got, `woocommerce_requests_total{endpoint="orders.list"} 1`) {
+		t.Errorf("call made while writing not counted:\n%s", got)
+	}
+}
+
+type spanKey struct{}
+
+type recordingInstrumentation struct {
+	NopInstrumentation
+	ended []string
+}
+
+func (r *recordingInstrumentation) StartCall(ctx context.Context, e Endpoint) context.Context {
+	return context.WithValue(ctx, spanKey{}, "span:"+e.String())
+}
+
+func (r *recordingInstrumentation) EndCall(ctx context.Context, e Endpoint, info CallInfo, err error) {
+	span, _ := ctx.Value(spanKey{}).(string)
+	r.ended = append(r.ended, span)
+}
+
+func TestInstrumentation_Spans(t *testing.T) {
+	var seen string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	rec := &recordingInstrumentation{}
+	WithInstrumentation(rec)(c)
+	WithMiddleware(func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			seen, _ = req.Context().Value(spanKey{}).(string)
+			return next(req)
+		}
+	})(c)
+
+	if _, err := c.Webhook.List(nil); err != nil {
+		t.Fatal(err)
+	}
+	if seen != "span:webhooks.list" || len(rec.ended) != 1 || rec.ended[0] != seen {
+		t.Errorf("span not propagated: request saw %q, ended %v", seen, rec.ended)
+	}
+}
@@ -0,0 +1,101 @@
+package woocommerce
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// Link is a link to a related resource, as listed in the _links of an object.
+// Embeddable links are included in _embedded when the request sets _embed.
+type Link struct {
+	Href       string `json:"href"`
+	Embeddable bool   `json:"embeddable,omitempty"`
+}
+
+// Links are the related resources of an object, which Client.Follow fetches.
+type Links struct {
+	Self       []Link `json:"self"`
+	Collection []Link `json:"collection"`
+	Customer   []Link `json:"customer"`
+	Up         []Link `json:"up"`
+}
+
+// Href returns the first href of the rel relation, self, collection,
+// customer or up, or "" if there is none.
+func (l Links) Href(rel string) string {
+	var links []Link
+	switch rel {
+	case "self":
+		links = l.Self
+	case "collection":
+		links = l.Collection
+	case "customer":
+		links = l.Customer
+	case "up":
+		links = l.Up
+	}
+	if len(links) == 0 {
+		return ""
+	}
+	return links[0].Href
+}
+
+// Embedded holds the related resources WooCommerce includes in _embedded
+// when a request sets _embed, by relation, e.g. "customer" or "up".
+type Embedded map[string][]json.RawMessage
+
+// Decode decodes the first resource embedded for rel into v:
+//
+//	order, err := client.Order.GetWithContext(ctx, id, woocommerce.GetOption{Embed: true})
+//	var customer woocommerce.Customer
+//	err = order.Embedded.Decode("customer", &customer)
+//
+// WooCommerce embeds an error instead of the resource when it could not be
+// read, which is returned as a ResponseError.
+func (e Embedded) Decode(rel string, v interface{}) error {
+	if len(e[rel]) == 0 {
+		return fmt.Errorf("woocommerce: no %q resource embedded", rel)
+	}
+	raw := e[rel][0]
+	var embedErr struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Data    struct {
+			Status int `json:"status"`
+		} `json:"data"`
+	}
+	if json.Unmarshal(raw, &embedErr) == nil && embedErr.Code != "" && embedErr.Message != "" && embedErr.Data.Status != 0 {
+		return ResponseError{Status: embedErr.Data.Status, Code: embedErr.Code, Message: embedErr.Message}
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// Follow gets the resource at href, such as order.Links.Href("customer"),
+// and decodes it into v. The request goes through the client's
+// authentication, retries and middleware, so href must point to the client's
+// store.
+func (c *Client) Follow(href string, v interface{}) error {
+	return c.FollowWithContext(context.Background(), href, v)
+}
+
+// FollowWithContext is like Follow but the request is bound to ctx.
+func (c *Client) FollowWithContext(ctx context.Context, href string, v interface{}) error {
+	u, err := url.Parse(href)
+	if err != nil {
+		return err
+	}
+	if u.Host != "" && (u.Host != c.baseURL.Host || u.Scheme != c.baseURL.Scheme) {
+		return fmt.Errorf("woocommerce: refusing to follow %s outside of %s://%s", href, c.baseURL.Scheme, c.baseURL.Host)
+	}
+	if u.Path == "" {
+		return fmt.Errorf("woocommerce: no path in link %q", href)
+	}
+	req, err := c.NewRequestWithContext(ctx, "GET", u.EscapedPath(), nil, u.Query())
+	if err != nil {
+		return err
+	}
+	_, err = c.doGetHeaders(req, v)
+	return err
+}
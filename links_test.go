@@ -0,0 +1,84 @@
+package woocommerce
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestClient_Follow(t *testing.T) {
+	var embed []string
+	var c *Client
+	c = newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, ok := r.BasicAuth(); !ok {
+			t.Errorf("%s sent without credentials", r.URL)
+		}
+		switch r.URL.Path {
+		case "/wp-json/wc/v3/orders/5":
+			embed = append(embed, r.URL.Query().Get("_embed"))
+			fmt.Fprintf(w, `{"id":5,"customer_id":12,
+				"_links":{"self":[{"href":"%[1]s/wp-json/wc/v3/orders/5"}],
+					"customer":[{"href":"%[1]s/wp-json/wc/v3/customers/12","embeddable":true}]},
+				"_embedded":{"customer":[{"id":12,"email":"jane@example.com"}],
+					"up":[{"code":"rest_forbidden","message":"Sorry.","data":{"status":403}}]}}`, c.baseURL)
+		case "/wp-json/wc/v3/customers/12":
+			if r.URL.Query().Get("context") != "edit" {
+				t.Errorf("link query lost: %s", r.URL)
+			}
+			fmt.Fprint(w, `{"id":12,"email":"jane@example.com"}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	}))
+	ctx := context.Background()
+
+	order, err := c.Order.GetWithContext(ctx, 5, GetOption{Embed: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(embed) != "[true]" {
+		t.Errorf("_embed = %v", embed)
+	}
+	if !order.Links.Customer[0].Embeddable {
+		t.Errorf("customer link not embeddable: %+v", order.Links)
+	}
+
+	var embedded Customer
+	if err := order.Embedded.Decode("customer", &embedded); err != nil || embedded.ID != 12 {
+		t.Errorf("embedded customer %+v, %v", embedded, err)
+	}
+	var parent Order
+	if err := order.Embedded.Decode("up", &parent); !errors.Is(err, ErrForbidden) {
+		t.Errorf("embedded error = %v, want ErrForbidden", err)
+	}
+	if err := order.Embedded.Decode("collection", &parent); err == nil {
+		t.Error("expected an error for a missing relation")
+	}
+
+	var customer Customer
+	if err := c.FollowWithContext(ctx, order.Links.Href("customer")+"?context=edit", &customer); err != nil {
+		t.Fatal(err)
+	}
+	if customer.Email != "jane@example.com" {
+		t.Errorf("followed customer %+v", customer)
+	}
+}
+
+func TestClient_FollowOtherHost(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}))
+	var v map[string]interface{}
+	if err := c.Follow("https://attacker.example/wp-json/wc/v3/customers/1", &v); err == nil {
+		t.Error("expected an error following a link to another host")
+	}
+}
+
+func TestLinks_Href(t *testing.T) {
+	l := Links{Up: []Link{{Href: "https://shop/wp-json/wc/v3/orders/1"}}}
+	if l.Href("up") != "https://shop/wp-json/wc/v3/orders/1" || l.Href("self") != "" || l.Href("nope") != "" {
+		t.Errorf("unexpected hrefs for %+v", l)
+	}
+}
@@ -0,0 +1,125 @@
+package woocommerce
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Handler sends a single HTTP request and returns its response.
+type Handler func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the Handler that sends each request, see WithMiddleware
+// option. It may modify the request before calling next, inspect or replace
+// the response, or not call next at all. A middleware runs once per attempt,
+// so retries go through it again.
+//
+// Requests signed with OAuth 1.0a must not have their URL changed, since the
+// query string is part of the signature.
+type Middleware func(next Handler) Handler
+
+// Endpoint identifies the API endpoint a request targets, decoded from its path.
+type Endpoint struct {
+	// Service is the resource path without IDs, e.g. "orders" or "products/variations".
+	Service string
+	// Operation is one of "list", "get", "create", "update", "delete" or "batch".
+	Operation string
+	// Route is the path relative to the API prefix with IDs replaced by {id},
+	// e.g. "products/{id}/variations/{id}".
+	Route string
+	// ID is the last ID in the path, if any.
+	ID string
+}
+
+// String returns the endpoint as "service.operation", e.g. "orders.list".
+func (e Endpoint) String() string {
+	return e.Service + "." + e.Operation
+}
+
+type endpointKey struct{}
+
+// EndpointFromContext returns the endpoint of the request whose context is
+// ctx. It is set on every request the client sends, so middlewares can read
+// it from req.Context().
+func EndpointFromContext(ctx context.Context) (Endpoint, bool) {
+	e, ok := ctx.Value(endpointKey{}).(Endpoint)
+	return e, ok
+}
+
+// endpoint decodes the endpoint of req from its method and URL path.
+func (c *Client) endpoint(req *http.Request) Endpoint {
+	p := strings.TrimPrefix(req.URL.Path, c.pathPrefix)
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+
+	var route, service []string
+	var id string
+	lastIsID := false
+	for i, s := range segments {
+		if s == "" {
+			continue
+		}
+		lastIsID = isID(s) || (i > 0 && stringIDResources[segments[i-1]])
+		if lastIsID {
+			route = append(route, "{id}")
+			id = s
+			continue
+		}
+		route = append(route, s)
+		service = append(service, s)
+	}
+
+	e := Endpoint{Route: strings.Join(route, "/"), ID: id}
+	if n := len(service); n > 0 && service[n-1] == "batch" && !lastIsID {
+		e.Operation = "batch"
+		service = service[:n-1]
+	}
+	e.Service = strings.Join(service, "/")
+	if e.Operation != "" {
+		return e
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		if lastIsID {
+			e.Operation = "get"
+		} else {
+			e.Operation = "list"
+		}
+	case http.MethodPost:
+		e.Operation = "create"
+	case http.MethodPut, http.MethodPatch:
+		e.Operation = "update"
+	case http.MethodDelete:
+		e.Operation = "delete"
+	default:
+		e.Operation = strings.ToLower(req.Method)
+	}
+	return e
+}
+
+// stringIDResources are the resources whose items have non numeric IDs,
+// such as the "paypal" payment gateway or downloaded file names.
+var stringIDResources = map[string]bool{
+	paymentGatewayBasePath: true,
+	filesBasePath:          true,
+	fileMetaBasePath:       true,
+}
+
+func isID(s string) bool {
+	_, err := strconv.ParseInt(s, 10, 64)
+	return err == nil
+}
+
+// handler returns c.Client.Do wrapped by the client's middlewares, the first
+// one given being the outermost. In dry-run mode, the mutating requests reach
+// the recorder instead of c.Client.Do.
+func (c *Client) handler() Handler {
+	h := Handler(c.Client.Do)
+	if c.dryRun != nil {
+		h = c.dryRun.handler(h)
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+	return h
+}
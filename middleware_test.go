@@ -0,0 +1,108 @@
+package woocommerce
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_WithMiddleware(t *testing.T) {
+	var header string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Audit")
+		w.Write([]byte(`{"id":3}`))
+	}))
+
+	var order []string
+	var endpoints []string
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, name+">")
+				resp, err := next(req)
+				order = append(order, "<"+name)
+				return resp, err
+			}
+		}
+	}
+	audit := func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			e, _ := EndpointFromContext(req.Context())
+			endpoints = append(endpoints, e.String())
+			req.Header.Set("X-Audit", e.Route)
+			return next(req)
+		}
+	}
+	WithMiddleware(trace("a"), trace("b"), audit)(c)
+
+	if _, err := c.Order.Update(&Order{ID: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(order, " "); got != "a> b> <b <a" {
+		t.Errorf("middleware order %q", got)
+	}
+	if header != "orders/{id}" || endpoints[0] != "orders.update" {
+		t.Errorf("header %q, endpoints %v", header, endpoints)
+	}
+}
+
+func TestClient_MiddlewareFaultInjection(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":1}`))
+	}), WithRetryPolicy(testRetryPolicy(clock)))
+
+	calls := 0
+	WithMiddleware(func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				rec := httptest.NewRecorder()
+				rec.WriteHeader(http.StatusServiceUnavailable)
+				return rec.Result(), nil
+			}
+			return next(req)
+		}
+	})(c)
+
+	if _, err := c.Product.Get(1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("middleware ran %d times, want once per attempt", calls)
+	}
+
+	WithMiddleware(func(Handler) Handler {
+		return func(*http.Request) (*http.Response, error) { return nil, nil }
+	})(c)
+	if _, err := c.Product.Get(1, nil); err != errNoResponse {
+		t.Errorf("expected errNoResponse, got %v", err)
+	}
+}
+
+func TestClient_Endpoint(t *testing.T) {
+	c := NewClient(App{}, "https://shop.example.com", WithLog(&LeveledLogger{}))
+	tests := []struct {
+		method, path, want, route string
+	}{
+		{"GET", "/wp-json/wc/v3/orders", "orders.list", "orders"},
+		{"GET", "/wp-json/wc/v3/orders/12", "orders.get", "orders/{id}"},
+		{"POST", "/wp-json/wc/v3/orders/12/notes", "orders/notes.create", "orders/{id}/notes"},
+		{"DELETE", "/wp-json/wc/v3/products/1/variations/2", "products/variations.delete", "products/{id}/variations/{id}"},
+		{"POST", "/wp-json/wc/v3/products/categories/batch", "products/categories.batch", "products/categories/batch"},
+		{"GET", "/wp-json/wc/v3/payment_gateways/paypal", "payment_gateways.get", "payment_gateways/{id}"},
+		{"GET", "/wp-json/wc/v3/payment_gateways", "payment_gateways.list", "payment_gateways"},
+		{"GET", "/wp-json/wc/v3/download/report.csv", "download.get", "download/{id}"},
+		{"GET", "/wp-json/wc/v3/download-meta/report.csv", "download-meta.get", "download-meta/{id}"},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(tt.method, "https://shop.example.com"+tt.path, io.NopCloser(strings.NewReader("")))
+		e := c.endpoint(req)
+		if e.String() != tt.want || e.Route != tt.route {
+			t.Errorf("%s %s: got %s (%s), want %s (%s)", tt.method, tt.path, e, e.Route, tt.want, tt.route)
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package woocommerce
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Money is an exact decimal amount, such as an order total or a product
+// price. WooCommerce sends amounts as strings with the store's decimal
+// places, e.g. "1234567.89", which Money keeps digit for digit, so decoding
+// and encoding an object does not change its amounts.
+//
+// The zero Money is an unset amount, encoded as "". Amounts of orders and
+// subscriptions carry the object's currency.
+type Money struct {
+	units    int64  // the amount in 10^-places
+	places   int    // decimal places
+	currency string // ISO 4217 code, if known
+	valid    bool   // false for an unset amount
+}
+
+// ErrMixedCurrencies is returned comparing amounts in different currencies.
+var ErrMixedCurrencies = errors.New("woocommerce: amounts in different currencies")
+
+// maxPlaces bounds the decimal places of an amount, so that 10^places fits
+// in an int64.
+const maxPlaces = 18
+
+// NewMoney returns the amount units * 10^-places, e.g. NewMoney(1999, 2,
+// "BRL") for R$ 19.99.
+func NewMoney(units int64, places int, currency string) Money {
+	if places < 0 || places > maxPlaces {
+		panic(fmt.Sprintf("woocommerce: %d decimal places out of range", places))
+	}
+	return Money{units: units, places: places, currency: currency, valid: true}
+}
+
+// ParseMoney parses a decimal amount such as "19.99", "-5" or "0.125". The
+// empty string is the unset amount.
+func ParseMoney(s string) (Money, error) {
+	if s == "" {
+		return Money{}, nil
+	}
+	digits, neg := s, false
+	switch digits[0] {
+	case '-':
+		digits, neg = digits[1:], true
+	case '+':
+		digits = digits[1:]
+	}
+	whole, frac, _ := strings.Cut(digits, ".")
+	if whole == "" && frac == "" || len(frac) > maxPlaces || !isDigits(whole) || !isDigits(frac) {
+		return Money{}, fmt.Errorf("woocommerce: invalid amount %q", s)
+	}
+	units, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("woocommerce: amount %q out of range", s)
+	}
+	if neg {
+		units = -units
+	}
+	return Money{units: units, places: len(frac), valid: true}, nil
+}
+
+// MustParseMoney is like ParseMoney but panics if s is not an amount.
+func MustParseMoney(s string) Money {
+	m, err := ParseMoney(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSet reports whether m holds an amount, which a zero amount does.
+func (m Money) IsSet() bool {
+	return m.valid
+}
+
+// IsZero reports whether m is unset, the zero Money. Fields tagged omitzero
+// are not encoded then, while amounts set to 0 are. Use Sign to test for a
+// zero amount.
+func (m Money) IsZero() bool {
+	return !m.valid
+}
+
+// Currency returns the currency of m, or "" if it is not known.
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// WithCurrency returns m in currency.
+func (m Money) WithCurrency(currency string) Money {
+	m.currency = currency
+	return m
+}
+
+// Places returns the number of decimal places of m.
+func (m Money) Places() int {
+	return m.places
+}
+
+// Units returns m in 10^-Places() units, e.g. 1999 for 19.99.
+func (m Money) Units() int64 {
+	return m.units
+}
+
+// Sign returns -1, 0 or 1 as m is negative, zero or positive.
+func (m Money) Sign() int {
+	switch {
+	case m.units < 0:
+		return -1
+	case m.units > 0:
+		return 1
+	}
+	return 0
+}
+
+// Add returns m + n, with the decimal places of the most precise of both.
+// It panics if they are in different currencies or the sum overflows.
+func (m Money) Add(n Money) Money {
+	a, b, currency, err := align(m, n)
+	if err != nil {
+		panic(err)
+	}
+	sum := a.units + b.units
+	if (sum > a.units) != (b.units > 0) {
+		panic("woocommerce: amount overflow")
+	}
+	return Money{units: sum, places: a.places, currency: currency, valid: m.valid || n.valid}
+}
+
+// Sub returns m - n, like Add.
+func (m Money) Sub(n Money) Money {
+	return m.Add(n.Neg())
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	if m.units == math.MinInt64 {
+		panic("woocommerce: amount overflow")
+	}
+	m.units = -m.units
+	return m
+}
+
+// Mul returns m * n, such as the total of n items priced m.
+func (m Money) Mul(n int64) Money {
+	units := m.units * n
+	if m.units != 0 && (units/m.units != n || (m.units == -1 && n == math.MinInt64)) {
+		panic("woocommerce: amount overflow")
+	}
+	m.units = units
+	return m
+}
+
+// Round returns m rounded half away from zero to places decimal places,
+// such as the store's.
+func (m Money) Round(places int) Money {
+	if places >= m.places {
+		return m.rescale(places)
+	}
+	div := pow10(m.places - places)
+	units, rem := m.units/div, m.units%div
+	if rem >= div-rem && rem > 0 {
+		units++
+	} else if -rem >= div+rem && rem < 0 {
+		units--
+	}
+	return Money{units: units, places: places, currency: m.currency, valid: m.valid}
+}
+
+// Cmp compares m and n, whatever their decimal places, and returns -1, 0 or
+// 1 as m is less than, equal to or greater than n. It returns an error
+// matching ErrMixedCurrencies if they are in different currencies.
+func (m Money) Cmp(n Money) (int, error) {
+	a, b, _, err := align(m, n)
+	switch {
+	case err != nil:
+		return 0, err
+	case a.units < b.units:
+		return -1, nil
+	case a.units > b.units:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Equal reports whether m and n are the same amount, e.g. 10.5 and 10.50.
+// Amounts in different currencies are never equal.
+func (m Money) Equal(n Money) bool {
+	c, err := m.Cmp(n)
+	return err == nil && c == 0
+}
+
+// Float64 returns m as the nearest float64, for display or statistics only.
+func (m Money) Float64() float64 {
+	f, _ := strconv.ParseFloat(m.String(), 64)
+	return f
+}
+
+// String returns m with its decimal places, e.g. "19.90", or "" if it is
+// unset.
+func (m Money) String() string {
+	if !m.valid {
+		return ""
+	}
+	s := strconv.FormatInt(m.units, 10)
+	if m.places == 0 {
+		return s
+	}
+	sign := ""
+	if m.units < 0 {
+		sign, s = "-", s[1:]
+	}
+	if len(s) <= m.places {
+		s = strings.Repeat("0", m.places-len(s)+1) + s
+	}
+	return sign + s[:len(s)-m.places] + "." + s[len(s)-m.places:]
+}
+
+// MarshalJSON encodes m as a string, like WooCommerce does.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON decodes a string or number amount. Empty strings and null
+// leave m unset.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		*m = Money{}
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	parsed, err := ParseMoney(strings.TrimSpace(s))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// rescale returns m with places decimal places, which must not be fewer than
+// those of m.
+func (m Money) rescale(places int) Money {
+	if places == m.places {
+		return m
+	}
+	mul := pow10(places - m.places)
+	units := m.units * mul
+	if units/mul != m.units {
+		panic("woocommerce: amount overflow")
+	}
+	m.units, m.places = units, places
+	return m
+}
+
+// align returns m and n with the same decimal places, and their currency.
+func align(m, n Money) (Money, Money, string, error) {
+	currency := m.currency
+	switch {
+	case currency == "":
+		currency = n.currency
+	case n.currency != "" && n.currency != currency:
+		return m, n, "", fmt.Errorf("%w: %s and %s", ErrMixedCurrencies, m.currency, n.currency)
+	}
+	places := max(m.places, n.places)
+	return m.rescale(places), n.rescale(places), currency, nil
+}
+
+func pow10(n int) int64 {
+	p := int64(1)
+	for range n {
+		p *= 10
+	}
+	return p
+}
+
+var moneyType = reflect.TypeFor[Money]()
+
+// setCurrency sets the currency of the unset-currency Money values in v, a
+// pointer to an order or subscription, their line items included.
+func setCurrency(v reflect.Value, currency string) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			setCurrency(v.Elem(), currency)
+		}
+	case reflect.Slice:
+		for i := range v.Len() {
+			setCurrency(v.Index(i), currency)
+		}
+	case reflect.Struct:
+		if v.Type() == moneyType {
+			if m := v.Addr().Interface().(*Money); m.currency == "" {
+				m.currency = currency
+			}
+			return
+		}
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				setCurrency(v.Field(i), currency)
+			}
+		}
+	}
+}
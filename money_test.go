@@ -0,0 +1,145 @@
+package woocommerce
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"19.99", "19.99", false},
+		{"1234567890123.45", "1234567890123.45", false},
+		{"-0.05", "-0.05", false},
+		{"+7", "7", false},
+		{"10.", "10", false},
+		{".5", "0.5", false},
+		{"0.0000", "0.0000", false},
+		{"", "", false},
+		{"1,5", "", true},
+		{"1e3", "", true},
+		{".", "", true},
+		{"-", "", true},
+		{"99999999999999999999", "", true},
+	}
+	for _, tt := range tests {
+		m, err := ParseMoney(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseMoney(%q) error = %v", tt.in, err)
+			continue
+		}
+		if got := m.String(); !tt.wantErr && got != tt.want {
+			t.Errorf("ParseMoney(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	price := MustParseMoney("19.99")
+	if got := price.Mul(3).String(); got != "59.97" {
+		t.Errorf("Mul = %s", got)
+	}
+	if got := price.Add(MustParseMoney("0.015")).String(); got != "20.005" {
+		t.Errorf("Add = %s", got)
+	}
+	if got := price.Sub(MustParseMoney("20")).String(); got != "-0.01" {
+		t.Errorf("Sub = %s", got)
+	}
+	// float32 could not hold this total to the cent.
+	total := MustParseMoney("123456.78").Add(MustParseMoney("0.01"))
+	if got := total.String(); got != "123456.79" {
+		t.Errorf("total = %s", got)
+	}
+	for in, want := range map[string]string{"2.345": "2.35", "2.344": "2.34", "-2.345": "-2.35", "0.005": "0.01", "7": "7.00"} {
+		if got := MustParseMoney(in).Round(2).String(); got != want {
+			t.Errorf("Round(%s) = %s, want %s", in, got, want)
+		}
+	}
+	if c, err := MustParseMoney("9.99").Cmp(MustParseMoney("10")); !MustParseMoney("10.5").Equal(MustParseMoney("10.50")) || c != -1 || err != nil {
+		t.Error("comparison ignores decimal places")
+	}
+	if _, err := price.WithCurrency("BRL").Cmp(price.WithCurrency("USD")); !errors.Is(err, ErrMixedCurrencies) {
+		t.Errorf("Cmp of BRL and USD amounts = %v", err)
+	}
+	if price.WithCurrency("BRL").Equal(price.WithCurrency("USD")) || !price.WithCurrency("BRL").Equal(price) {
+		t.Error("Equal ignores currencies")
+	}
+	if (Money{}).IsSet() || !(Money{}).IsZero() || !MustParseMoney("0").IsSet() || MustParseMoney("0.00").IsZero() {
+		t.Error("unset and zero amounts mixed up")
+	}
+	if got := NewMoney(5, 3, "BRL"); got.String() != "0.005" || got.Currency() != "BRL" {
+		t.Errorf("NewMoney = %s %s", got, got.Currency())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic adding amounts in different currencies")
+		}
+	}()
+	price.WithCurrency("BRL").Add(price.WithCurrency("USD"))
+}
+
+func TestMoney_JSON(t *testing.T) {
+	in := `{"id":1,"currency":"BRL","total":"123456789.10","discount_total":"0.00",
+		"line_items":[{"id":2,"total":"99.9900","price":33.33}],"coupon_lines":[{"nominal_amount":5}]}`
+	var o Order
+	if err := json.Unmarshal([]byte(in), &o); err != nil {
+		t.Fatal(err)
+	}
+	if o.Total.String() != "123456789.10" || o.Total.Currency() != "BRL" {
+		t.Errorf("total = %s %s", o.Total, o.Total.Currency())
+	}
+	item := o.LineItems[0]
+	if item.Total.String() != "99.9900" || item.Price.String() != "33.33" || item.Price.Currency() != "BRL" {
+		t.Errorf("line item %+v", item)
+	}
+
+	out, err := json.Marshal(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back Order
+	if err := json.Unmarshal(out, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back.Total != o.Total || back.LineItems[0].Total != item.Total || back.CouponLines[0].NominalAmount.String() != "5" {
+		t.Errorf("amounts changed in round trip: %s", out)
+	}
+	fields := fieldsOf(t, out)
+	if string(fields["discount_total"]) != `"0.00"` {
+		t.Errorf("zero amount not encoded in %s", out)
+	}
+	if _, ok := fields["shipping_total"]; ok {
+		t.Errorf("unset amount encoded in %s", out)
+	}
+
+	free, _ := json.Marshal(LineItem{ProductID: 4, Quantity: 1, Total: MustParseMoney("0.00")})
+	if string(fieldsOf(t, free)["total"]) != `"0.00"` {
+		t.Errorf("free line item encoded as %s", free)
+	}
+
+	var p Product
+	if err := json.Unmarshal([]byte(`{"price":"10","regular_price":"10","sale_price":""}`), &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.SalePrice.IsSet() || p.Price.String() != "10" {
+		t.Errorf("prices %s %s", p.Price, p.SalePrice)
+	}
+	out, _ = json.Marshal(p)
+	if got := fieldsOf(t, out); string(got["sale_price"]) != `""` || string(got["price"]) != `"10"` {
+		t.Errorf("prices encoded as %s", out)
+	}
+}
+
+func fieldsOf(t *testing.T, data []byte) map[string]json.RawMessage {
+	t.Helper()
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("invalid JSON %s: %v", data, err)
+	}
+	return fields
+}
@@ -1,66 +1,96 @@
 package woocommerce
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 const (
-  orderNoteBasePath = "orders"
+	orderNoteBasePath = "orders"
 )
 
 // OrderNoteService operate Woo-Commerce Order note, eg: create, view, and delete individual order notes.
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#order-notes
 type OrderNoteService interface {
-  Create(orderId int64, text string) (*OrderNote, error)
-  Get(orderId int64, noteId int64) (*OrderNote, error)
-  List(orderId int64, options interface{}) (*[]OrderNote, error)
-  Delete(orderId int64, noteId int64, options interface{}) (*OrderNote, error)
+	Create(orderId int64, text string) (*OrderNote, error)
+	Get(orderId int64, noteId int64, options ...GetOption) (*OrderNote, error)
+	List(orderId int64, options interface{}) (*[]OrderNote, error)
+	Delete(orderId int64, noteId int64, options interface{}) (*OrderNote, error)
+	CreateWithContext(ctx context.Context, orderId int64, text string) (*OrderNote, error)
+	GetWithContext(ctx context.Context, orderId int64, noteId int64, options ...GetOption) (*OrderNote, error)
+	ListWithContext(ctx context.Context, orderId int64, options interface{}) (*[]OrderNote, error)
+	DeleteWithContext(ctx context.Context, orderId int64, noteId int64, options interface{}) (*OrderNote, error)
 }
 
 // OrderNote represent a WooCommerce Order note
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#order-notes
 type OrderNote struct {
-  ID             int64  `json:"id,omitempty"`
-  Author         string `json:"author,omitempty"`
-  DateCreated    string `json:"date_created,omitempty"`
-  DateCreatedGmt string `json:"date_created_gmt,omitempty"`
-
-  Note         string `json:"note,omitempty"`
-  CustomerNote string `json:"customer_note,omitempty"`
-  AddedByUser  bool   `json:"added_by_user,omitempty"`
+	ID             int64  `json:"id,omitempty"`
+	Author         string `json:"author,omitempty"`
+	DateCreated    string `json:"date_created,omitempty"`
+	DateCreatedGmt string `json:"date_created_gmt,omitempty"`
+
+	Note         string `json:"note,omitempty"`
+	CustomerNote string `json:"customer_note,omitempty"`
+	AddedByUser  bool   `json:"added_by_user,omitempty"`
+
+	// Extra keeps the fields not modelled above, such as those of plugins.
+	Extra Extra `json:"-"`
+}
+
+// UnmarshalJSON decodes n, keeping the fields it does not model in Extra.
+func (n *OrderNote) UnmarshalJSON(data []byte) error {
+	type plain OrderNote
+	return unmarshalExtra(data, (*plain)(n), &n.Extra)
+}
+
+// MarshalJSON encodes n along with its Extra fields.
+func (n OrderNote) MarshalJSON() ([]byte, error) {
+	type plain OrderNote
+	return marshalExtra(plain(n), n.Extra)
 }
 
 type OrderNoteServiceOp struct {
-  client *Client
+	client *Client
+}
+
+func (n *OrderNoteServiceOp) resource(orderId int64) *Resource[OrderNote, ListOptions] {
+	return NewResource[OrderNote, ListOptions](n.client, fmt.Sprintf("%s/%d/notes", orderNoteBasePath, orderId), func(v *OrderNote) int64 { return v.ID })
 }
 
 func (n *OrderNoteServiceOp) Create(orderId int64, text string) (*OrderNote, error) {
-  path := fmt.Sprintf("%s/%d/notes", orderNoteBasePath, orderId)
-  resource := new(OrderNote)
-  insertOrderNote := OrderNote{
-    Note: text,
-  }
-  err := n.client.Post(path, insertOrderNote, resource)
-  return resource, err
+	return n.CreateWithContext(context.Background(), orderId, text)
+}
+
+func (n *OrderNoteServiceOp) CreateWithContext(ctx context.Context, orderId int64, text string) (*OrderNote, error) {
+	return n.resource(orderId).CreateWithContext(ctx, OrderNote{Note: text})
 }
 
-func (n *OrderNoteServiceOp) Get(orderId int64, noteId int64) (*OrderNote, error) {
-  path := fmt.Sprintf("%s/%d/notes/%d", orderNoteBasePath, orderId, noteId)
-  resource := new(OrderNote)
+// Get gets a note of an order, with the first of options, if any.
+func (n *OrderNoteServiceOp) Get(orderId int64, noteId int64, options ...GetOption) (*OrderNote, error) {
+	return n.GetWithContext(context.Background(), orderId, noteId, options...)
+}
 
-  err := n.client.Get(path, resource, nil)
-  return resource, err
+func (n *OrderNoteServiceOp) GetWithContext(ctx context.Context, orderId int64, noteId int64, options ...GetOption) (*OrderNote, error) {
+	return n.resource(orderId).GetWithContext(ctx, noteId, firstGetOption(options))
 }
 
 func (n *OrderNoteServiceOp) List(orderId int64, options interface{}) (*[]OrderNote, error) {
-  path := fmt.Sprintf("%s/%d/notes", orderNoteBasePath, orderId)
-  resource := new([]OrderNote)
+	return n.ListWithContext(context.Background(), orderId, options)
+}
 
-  err := n.client.Get(path, resource, options)
-  return resource, err
+func (n *OrderNoteServiceOp) ListWithContext(ctx context.Context, orderId int64, options interface{}) (*[]OrderNote, error) {
+	notes, err := n.resource(orderId).ListWithContext(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	return &notes, nil
 }
 
 func (n *OrderNoteServiceOp) Delete(orderId int64, noteId int64, options interface{}) (*OrderNote, error) {
-  path := fmt.Sprintf("%s/%d/notes/%d", orderNoteBasePath, orderId, noteId)
-  resource := new(OrderNote)
-  err := n.client.Delete(path, options, &resource)
-  return resource, err
+	return n.DeleteWithContext(context.Background(), orderId, noteId, options)
+}
+
+func (n *OrderNoteServiceOp) DeleteWithContext(ctx context.Context, orderId int64, noteId int64, options interface{}) (*OrderNote, error) {
+	return n.resource(orderId).DeleteWithContext(ctx, noteId, options)
 }
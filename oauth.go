@@ -0,0 +1,232 @@
+package woocommerce
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureMethod is the OAuth 1.0a signature method used to sign requests.
+type SignatureMethod string
+
+const (
+	SignatureHMACSHA1   SignatureMethod = "HMAC-SHA1"
+	SignatureHMACSHA256 SignatureMethod = "HMAC-SHA256"
+)
+
+// oauth1Signer signs requests with one-legged OAuth 1.0a, the only
+// authentication WooCommerce accepts for stores served over plain HTTP.
+// https://woocommerce.github.io/woocommerce-rest-api-docs/#authentication-over-http
+type oauth1Signer struct {
+	method SignatureMethod
+	now    func() time.Time
+	nonce  func() string
+}
+
+func newOAuth1Signer(method SignatureMethod) *oauth1Signer {
+	if method == "" {
+		method = SignatureHMACSHA256
+	}
+	return &oauth1Signer{
+		method: method,
+		now:    time.Now,
+		nonce:  randomNonce,
+	}
+}
+
+func randomNonce() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand never fails on supported platforms; fall back to the
+		// clock so a nonce is always produced.
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
+
+// sign replaces any previous oauth_* parameters on req with a fresh nonce,
+// timestamp and signature. It is safe to call again before a retry.
+func (s *oauth1Signer) sign(req *http.Request, consumerKey, consumerSecret string) error {
+	var mac func() hash.Hash
+	switch s.method {
+	case SignatureHMACSHA1:
+		mac = sha1.New
+	case SignatureHMACSHA256:
+		mac = sha256.New
+	default:
+		return fmt.Errorf("unsupported oauth signature method %q", s.method)
+	}
+
+	params, err := url.ParseQuery(req.URL.RawQuery)
+	if err != nil {
+		return err
+	}
+	for k := range params {
+		if strings.HasPrefix(k, "oauth_") {
+			delete(params, k)
+		}
+	}
+	params.Set("oauth_consumer_key", consumerKey)
+	params.Set("oauth_nonce", s.nonce())
+	params.Set("oauth_signature_method", string(s.method))
+	params.Set("oauth_timestamp", strconv.FormatInt(s.now().Unix(), 10))
+
+	php := phpParams(params)
+	base := strings.Join([]string{
+		strings.ToUpper(req.Method),
+		rfc3986Escape(oauthBaseURL(req.URL)),
+		oauthParamString(php),
+	}, "&")
+
+	h := hmac.New(mac, []byte(consumerSecret+"&"))
+	h.Write([]byte(base))
+	signature := base64.StdEncoding.EncodeToString(h.Sum(nil))
+
+	req.URL.RawQuery = encodePHPParams(php) + "&oauth_signature=" + url.QueryEscape(signature)
+	return nil
+}
+
+// phpParam is a query parameter as PHP parses it: either a scalar or a list
+// of values sent as key[0]=a&key[1]=b.
+type phpParam struct {
+	key    string
+	values []string
+	array  bool
+}
+
+// phpParams converts the query produced by go-querystring into the shape PHP
+// sees on the server. Repeated keys, which PHP would collapse to the last
+// value, are turned into indexed arrays so both the store and the signature
+// see every value. Indexed keys such as key[0], left by a previous sign, are
+// read back as the same arrays, so re-signing gives the same signature.
+func phpParams(values url.Values) []phpParam {
+	merged := map[string]*phpParam{}
+	indexes := map[string][]int{}
+	for k, vs := range values {
+		key, array := strings.TrimSuffix(k, "[]"), strings.HasSuffix(k, "[]")
+		index := -1
+		if name, n, ok := phpIndex(k); ok {
+			key, array, index = name, true, n
+		}
+		p, ok := merged[key]
+		if !ok {
+			p = &phpParam{key: key}
+			merged[key] = p
+		}
+		for range vs {
+			indexes[key] = append(indexes[key], index)
+		}
+		p.values = append(p.values, vs...)
+		p.array = p.array || array || len(p.values) > 1
+	}
+	params := make([]phpParam, 0, len(merged))
+	for key, p := range merged {
+		// Keep the values of key[0], key[1], ... in index order; plain and
+		// key[] values, indexed -1, stay first in their original order.
+		index := indexes[key]
+		sort.Stable(byIndex{index, p.values})
+		params = append(params, *p)
+	}
+	// WooCommerce sorts with strcmp, i.e. by byte order of the decoded keys.
+	sort.Slice(params, func(i, j int) bool { return params[i].key < params[j].key })
+	return params
+}
+
+// phpIndex splits an indexed key such as status[1] into its name and index.
+func phpIndex(k string) (string, int, bool) {
+	name, rest, ok := strings.Cut(k, "[")
+	if !ok || name == "" || !strings.HasSuffix(rest, "]") {
+		return "", 0, false
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(rest, "]"))
+	if err != nil || n < 0 {
+		return "", 0, false
+	}
+	return name, n, true
+}
+
+// byIndex sorts values by the matching index.
+type byIndex struct {
+	index  []int
+	values []string
+}
+
+func (b byIndex) Len() int           { return len(b.index) }
+func (b byIndex) Less(i, j int) bool { return b.index[i] < b.index[j] }
+func (b byIndex) Swap(i, j int) {
+	b.index[i], b.index[j] = b.index[j], b.index[i]
+	b.values[i], b.values[j] = b.values[j], b.values[i]
+}
+
+func encodePHPParams(params []phpParam) string {
+	parts := make([]string, 0, len(params))
+	for _, p := range params {
+		if !p.array {
+			parts = append(parts, url.QueryEscape(p.key)+"="+url.QueryEscape(p.values[0]))
+			continue
+		}
+		for i, v := range p.values {
+			parts = append(parts, url.QueryEscape(fmt.Sprintf("%s[%d]", p.key, i))+"="+url.QueryEscape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// oauthParamString builds the parameter part of the signature base string the
+// way WooCommerce's check_oauth_signature does: keys and values are encoded,
+// each key=value pair is encoded again as a whole, and the pairs are joined
+// with a pre-encoded "&".
+func oauthParamString(params []phpParam) string {
+	parts := make([]string, 0, len(params))
+	for _, p := range params {
+		key := rfc3986Escape(p.key)
+		if !p.array {
+			parts = append(parts, rfc3986Escape(key+"="+rfc3986Escape(p.values[0])))
+			continue
+		}
+		for i, v := range p.values {
+			parts = append(parts, rfc3986Escape(fmt.Sprintf("%s%%5B%d%%5D=%s", key, i, rfc3986Escape(v))))
+		}
+	}
+	return strings.Join(parts, "%26")
+}
+
+// oauthBaseURL returns the request URL without query or fragment.
+func oauthBaseURL(u *url.URL) string {
+	base := url.URL{
+		Scheme: strings.ToLower(u.Scheme),
+		Host:   strings.ToLower(u.Host),
+		Path:   u.Path,
+	}
+	if base.Path == "" {
+		base.Path = "/"
+	}
+	return base.String()
+}
+
+// rfc3986Escape percent-encodes everything but unreserved characters, matching
+// PHP's rawurlencode.
+func rfc3986Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
@@ -0,0 +1,100 @@
+package woocommerce
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOAuth1Signer_Sign(t *testing.T) {
+	s := newOAuth1Signer(SignatureHMACSHA256)
+	s.now = func() time.Time { return time.Unix(1700000000, 0) }
+	s.nonce = func() string { return "abc" }
+
+	req, _ := http.NewRequest("GET", "http://shop.example.com/wp-json/wc/v3/orders?per_page=10&search=a+b&status=processing&status=on-hold&after=2024-01-01T00:00:00&_fields=id,sku", nil)
+	if err := s.sign(req, "ck_x", "cs_y"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Computed with WooCommerce's check_oauth_signature, which encodes each
+	// key=value pair twice:
+	// GET&http%3A%2F%2Fshop.example.com%2Fwp-json%2Fwc%2Fv3%2Forders&_fields%3Did%252Csku%26after%3D2024-01-01T00%253A00%253A00%26...%26search%3Da%2520b%26status%255B0%255D%3Dprocessing%26status%255B1%255D%3Don-hold
+	q := req.URL.Query()
+	if got, want := q.Get("oauth_signature"), "Ibnvz2LDhyVxIJ1dPXvdAVkJS80hThmb7tBuA90RyUE="; got != want {
+		t.Errorf("oauth_signature = %q, want %q", got, want)
+	}
+	if q.Get("status[0]") != "processing" || q.Get("status[1]") != "on-hold" {
+		t.Errorf("repeated keys not sent as PHP array: %s", req.URL.RawQuery)
+	}
+	if q.Get("search") != "a b" || q.Get("oauth_consumer_key") != "ck_x" {
+		t.Errorf("unexpected query %s", req.URL.RawQuery)
+	}
+
+	// Re-signing with the same nonce and timestamp must give the same
+	// signature, array parameters included.
+	first := req.URL.RawQuery
+	if err := s.sign(req, "ck_x", "cs_y"); err != nil {
+		t.Fatal(err)
+	}
+	if req.URL.RawQuery != first {
+		t.Errorf("re-signed query %s, want %s", req.URL.RawQuery, first)
+	}
+
+	// Re-signing must replace, not duplicate, the oauth parameters.
+	s.nonce = func() string { return "def" }
+	if err := s.sign(req, "ck_x", "cs_y"); err != nil {
+		t.Fatal(err)
+	}
+	q = req.URL.Query()
+	if len(q["oauth_nonce"]) != 1 || q.Get("oauth_nonce") != "def" || len(q["oauth_signature"]) != 1 {
+		t.Errorf("re-sign left stale oauth parameters: %s", req.URL.RawQuery)
+	}
+}
+
+func TestPHPParams_Indexed(t *testing.T) {
+	params := phpParams(url.Values{"status[1]": {"b"}, "status[0]": {"a"}, "status[10]": {"c"}, "include[]": {"4"}, "page": {"2"}})
+	if len(params) != 3 {
+		t.Fatalf("params = %+v", params)
+	}
+	if p := params[2]; p.key != "status" || !p.array || strings.Join(p.values, ",") != "a,b,c" {
+		t.Errorf("status = %+v", p)
+	}
+	if p := params[0]; p.key != "include" || !p.array || p.values[0] != "4" {
+		t.Errorf("include = %+v", p)
+	}
+	if p := params[1]; p.key != "page" || p.array {
+		t.Errorf("page = %+v", p)
+	}
+}
+
+func TestClient_WithOAuth1(t *testing.T) {
+	var query url.Values
+	var authHeader string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		authHeader = r.Header.Get("Authorization")
+		w.Write([]byte(`[]`))
+	}), WithOAuth1(SignatureHMACSHA1))
+
+	_, err := c.Order.List(OrderListOption{Status: []string{"processing"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if authHeader != "" {
+		t.Errorf("expected no Authorization header, got %q", authHeader)
+	}
+	if query.Get("oauth_signature_method") != "HMAC-SHA1" || query.Get("oauth_signature") == "" {
+		t.Errorf("request not signed: %v", query)
+	}
+	if query.Get("oauth_consumer_key") != customerKey || query.Get("status") != "processing" {
+		t.Errorf("unexpected query %v", query)
+	}
+}
+
+func TestRFC3986Escape(t *testing.T) {
+	if got := rfc3986Escape("a b+c~d/é"); got != "a%20b%2Bc~d%2F%C3%A9" {
+		t.Errorf("rfc3986Escape = %q", got)
+	}
+}
@@ -2,6 +2,7 @@ package woocommerce
 
 import (
 	"fmt"
+	"log/slog"
 	"time"
 )
 
@@ -19,10 +20,20 @@ func WithVersion(apiVersion string) Option {
 	}
 }
 
-// WithRetry Timeout config option
+// WithRetry retries failed requests with DefaultRetryPolicy, making at most
+// retries attempts in total.
 func WithRetry(retries int) Option {
 	return func(c *Client) {
-		c.retries = retries
+		p := DefaultRetryPolicy()
+		p.MaxAttempts = max(retries, 1)
+		c.retry = p.withDefaults()
+	}
+}
+
+// WithRetryPolicy retry config option
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy.withDefaults()
 	}
 }
 
@@ -33,7 +44,8 @@ func WithLog(logger LeveledLoggerInterface) Option {
 	}
 }
 
-// SetLog updates the logger used by the client.
+// SetLog updates the logger used by the client. It must not be called while
+// requests are in flight.
 func (c *Client) SetLog(logger LeveledLoggerInterface) {
 	c.log = logger
 }
@@ -44,3 +56,93 @@ func WithTimeout(timeout time.Duration) Option {
 		c.Client.Timeout = timeout
 	}
 }
+
+// WithOAuth1 signs every request with one-legged OAuth 1.0a using the app's
+// consumer key and secret instead of sending them as Basic auth. WooCommerce
+// requires this for stores that are not served over HTTPS.
+func WithOAuth1(method SignatureMethod) Option {
+	return func(c *Client) {
+		c.oauth1 = newOAuth1Signer(method)
+	}
+}
+
+// WithRateLimit throttles the client to requestsPerSecond on average with
+// bursts of up to burst requests, shared by all its services. Callers block
+// until a request may be sent, and also while the store signals it is rate
+// limiting them.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Client) {
+		c.limiter = NewRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// WithRateLimiter uses limiter to throttle the client. Passing the same
+// limiter to several clients of one store makes them share its budget.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// WithMiddleware adds middlewares around the sending of every request. They
+// run in the order given, the first one seeing the request first and the
+// response last.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, mw...)
+	}
+}
+
+// WithRedaction replaces DefaultRedaction as the list of headers and fields
+// masked in the client's logs.
+func WithRedaction(r Redaction) Option {
+	return func(c *Client) {
+		c.redaction = r
+	}
+}
+
+// WithSlog logs through h instead of LeveledLogger, adding structured
+// attributes (method, endpoint, id, status, attempt, duration) to the
+// client's records.
+func WithSlog(h slog.Handler) Option {
+	return func(c *Client) {
+		c.log = NewSlogLogger(h)
+	}
+}
+
+// WithInstrumentation reports every API call to i, e.g. a Metrics or a
+// tracing bridge.
+func WithInstrumentation(i Instrumentation) Option {
+	return func(c *Client) {
+		c.instrumentation = i
+	}
+}
+
+// WithDecodeMode checks every response for schema drift, unknown fields,
+// coercions and values that failed to parse, which are logged as warnings
+// and set in CallInfo.Drift. In DecodeStrict mode, responses with unknown
+// fields or parse failures fail with a *DriftError.
+func WithDecodeMode(mode DecodeMode) Option {
+	return func(c *Client) {
+		c.decodeMode = mode
+	}
+}
+
+// WithStoreTimezone sets the store's timezone, in which WooCommerce sends
+// the dates without a _gmt suffix. See also Client.LoadStoreTimezone.
+func WithStoreTimezone(loc *time.Location) Option {
+	return func(c *Client) {
+		c.location.Store(loc)
+	}
+}
+
+// WithDryRun records the POST, PUT and DELETE requests, batches included, in
+// d instead of sending them, answering each with a synthesized response so
+// the calling code runs to completion. GET requests are still sent to the
+// store. Middlewares, logging and instrumentation see the recorded requests
+// as they would the real ones.
+func WithDryRun(d *DryRun) Option {
+	return func(c *Client) {
+		c.dryRun = d
+	}
+}
@@ -1,6 +1,7 @@
 package woocommerce
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -18,14 +19,25 @@ type OrderService interface {
 	Get(orderId int64, options interface{}) (*Order, error)
 	List(options interface{}) ([]Order, error)
 	Update(order *Order) (*Order, error)
+	Patch(order *Order, fields ...string) (*Order, error)
 	Delete(orderID int64, options interface{}) (*Order, error)
 	Batch(option OrderBatchOption) (*OrderBatchResource, error)
 	ListWithPagination(options interface{}) ([]Order, *Pagination, error)
+	CreateWithContext(ctx context.Context, order Order) (*Order, error)
+	GetWithContext(ctx context.Context, orderId int64, options interface{}) (*Order, error)
+	ListWithContext(ctx context.Context, options interface{}) ([]Order, error)
+	UpdateWithContext(ctx context.Context, order *Order) (*Order, error)
+	PatchWithContext(ctx context.Context, order *Order, fields ...string) (*Order, error)
+	DeleteWithContext(ctx context.Context, orderID int64, options interface{}) (*Order, error)
+	BatchWithContext(ctx context.Context, option OrderBatchOption) (*OrderBatchResource, error)
+	ListWithPaginationWithContext(ctx context.Context, options interface{}) ([]Order, *Pagination, error)
+	Pager(ctx context.Context, options OrderListOption) *Pager[Order]
+	Base() *Resource[Order, OrderListOption]
 }
 
 // OrderServiceOp handles communication with the order related methods of WooCommerce'API
 type OrderServiceOp struct {
-	client *Client
+	*Resource[Order, OrderListOption]
 }
 
 // OrderListOption list all thee order list option request params
@@ -56,84 +68,97 @@ type OrderListOption struct {
 	Status        []string `url:"status,omitempty"`
 	Customer      int64    `url:"customer,omitempty"`
 	Product       int64    `url:"product,omitempty"`
-	Dp            int      `url:"id,omitempty"`
+	Dp            int      `url:"dp,omitempty"`
 }
 
 // OrderBatchOption setting  operate for order in batch way
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#batch-update-orders
-type OrderBatchOption struct {
-	Create []Order `json:"create,omitempty"`
-	Update []Order `json:"update,omitempty"`
-	Delete []int64 `json:"delete,omitempty"`
-}
+type OrderBatchOption = BatchOption[Order]
 
 // OrderBatchResource conservation the response struct for OrderBatchOption request
-type OrderBatchResource struct {
-	Create []*Order `json:"create,omitempty"`
-	Update []*Order `json:"update,omitempty"`
-	Delete []*Order `json:"delete,omitempty"`
-}
+type OrderBatchResource = BatchResource[Order]
 
 // Order represents a WooCommerce Order
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#order-properties
 type Order struct {
-	ID                 int64             `json:"id,omitempty"`
-	ParentId           int64             `json:"parent_id,omitempty"`
-	Number             string            `json:"number,omitempty"`
-	OrderKey           string            `json:"order_key,omitempty"`
-	CreatedVia         string            `json:"created_via,omitempty"`
-	Version            string            `json:"version,omitempty"`
-	Status             string            `json:"status,omitempty"`
-	Currency           string            `json:"currency,omitempty"`
-	DateCreated        StringTime        `json:"date_created,omitempty"`
-	DateCreatedGmt     StringTime        `json:"date_created_gmt,omitempty"`
-	DateModified       StringTime        `json:"date_modified,omitempty"`
-	DateModifiedGmt    StringTime        `json:"date_modified_gmt,omitempty"`
-	DiscountsTotal     StringFloat       `json:"discount_total,omitempty"`
-	DiscountsTax       StringFloat       `json:"discount_tax,omitempty"`
-	ShippingTotal      StringFloat       `json:"shipping_total,omitempty"`
-	ShippingTax        StringFloat       `json:"shipping_tax,omitempty"`
-	CartTax            StringFloat       `json:"cart_tax,omitempty"`
-	Total              StringFloat       `json:"total,omitempty"`
-	TotalTax           StringFloat       `json:"total_tax,omitempty"`
-	PricesIncludeTax   bool              `json:"prices_include_tax,omitempty"`
-	CustomerId         int64             `json:"customer_id,omitempty"`
-	CustomerIpAddress  string            `json:"customer_ip_address,omitempty"`
-	CustomerUserAgent  string            `json:"customer_user_agent,omitempty"`
-	CustomerNote       string            `json:"customer_note,omitempty"`
-	Billing            *Billing          `json:"billing,omitempty"`
-	Shipping           *Shipping         `json:"shipping,omitempty"`
-	PaymentMethod      string            `json:"payment_method,omitempty"`
-	PaymentMethodTitle string            `json:"payment_method_title,omitempty"`
-	TransactionId      string            `json:"transaction_id,omitempty"`
-	DatePaid           StringTime        `json:"date_paid,omitempty"`
-	DatePaidGmt        StringTime        `json:"date_paid_gmt,omitempty"`
-	DateCompleted      StringTime        `json:"date_completed,omitempty"`
-	DateCompletedGmt   StringTime        `json:"date_completed_gmt,omitempty"`
-	CartHash           string            `json:"cart_hash,omitempty"`
-	Meta               []MetaData        `json:"meta,omitempty"`
-	MetaData           []MetaData        `json:"meta_data,omitempty"`
-	Renewal            StringInt         `json:"renewal,omitempty"`
-	LineItems          []LineItem        `json:"line_items,omitempty"`
-	TaxLines           []TaxLine         `json:"tax_lines,omitempty"`
-	ShippingLines      []ShippingLines   `json:"shipping_lines,omitempty"`
-	FeeLines           []FeeLine         `json:"fee_lines,omitempty"`
-	CouponLines        []CouponLine      `json:"coupon_lines,omitempty"`
-	Refunds            []Refund          `json:"refunds,omitempty"`
-	PaymentUrl         string            `json:"payment_url,omitempty"`
-	CurrencySymbol     string            `json:"currency_symbol,omitempty"`
-	Links              Links             `json:"_links"`
-	SetPaid            bool              `json:"set_paid,omitempty"`
-	IsEditable         bool              `json:"is_editable,omitempty"`
-	NeedsPayment       bool              `json:"needs_payment,omitempty"`
-	NeedsProcessing    bool              `json:"needs_processing,omitempty"`
-	TrackingCode       string            `json:"correios_tracking_code,omitempty"`
-	OrderType          string            `json:"order_type,omitempty"`
-	Paghiper           *WC_Paghiper_Data `json:"wc_paghiper_data,omitempty"`
-	NFE                []NFE             `json:"nfe,omitempty"`
-	Paypal             *PaypalData       `json:"paypal,omitempty"`
+	ID                 int64           `json:"id,omitempty"`
+	ParentId           int64           `json:"parent_id,omitempty"`
+	Number             string          `json:"number,omitempty"`
+	OrderKey           string          `json:"order_key,omitempty"`
+	CreatedVia         string          `json:"created_via,omitempty"`
+	Version            string          `json:"version,omitempty"`
+	Status             string          `json:"status,omitempty"`
+	Currency           string          `json:"currency,omitempty"`
+	DateCreated        StringTime      `json:"date_created,omitempty"`
+	DateCreatedGmt     StringTime      `json:"date_created_gmt,omitempty"`
+	DateModified       StringTime      `json:"date_modified,omitempty"`
+	DateModifiedGmt    StringTime      `json:"date_modified_gmt,omitempty"`
+	DiscountsTotal     Money           `json:"discount_total,omitzero"`
+	DiscountsTax       Money           `json:"discount_tax,omitzero"`
+	ShippingTotal      Money           `json:"shipping_total,omitzero"`
+	ShippingTax        Money           `json:"shipping_tax,omitzero"`
+	CartTax            Money           `json:"cart_tax,omitzero"`
+	Total              Money           `json:"total,omitzero"`
+	TotalTax           Money           `json:"total_tax,omitzero"`
+	PricesIncludeTax   bool            `json:"prices_include_tax,omitempty"`
+	CustomerId         int64           `json:"customer_id,omitempty"`
+	CustomerIpAddress  string          `json:"customer_ip_address,omitempty"`
+	CustomerUserAgent  string          `json:"customer_user_agent,omitempty"`
+	CustomerNote       string          `json:"customer_note,omitempty"`
+	Billing            *Billing        `json:"billing,omitempty"`
+	Shipping           *Shipping       `json:"shipping,omitempty"`
+	PaymentMethod      string          `json:"payment_method,omitempty"`
+	PaymentMethodTitle string          `json:"payment_method_title,omitempty"`
+	TransactionId      string          `json:"transaction_id,omitempty"`
+	DatePaid           StringTime      `json:"date_paid,omitempty"`
+	DatePaidGmt        StringTime      `json:"date_paid_gmt,omitempty"`
+	DateCompleted      StringTime      `json:"date_completed,omitempty"`
+	DateCompletedGmt   StringTime      `json:"date_completed_gmt,omitempty"`
+	CartHash           string          `json:"cart_hash,omitempty"`
+	Meta               []MetaData      `json:"meta,omitempty"`
+	MetaData           []MetaData      `json:"meta_data,omitempty"`
+	Renewal            StringInt       `json:"renewal,omitempty"`
+	LineItems          []LineItem      `json:"line_items,omitempty"`
+	TaxLines           []TaxLine       `json:"tax_lines,omitempty"`
+	ShippingLines      []ShippingLines `json:"shipping_lines,omitempty"`
+	FeeLines           []FeeLine       `json:"fee_lines,omitempty"`
+	CouponLines        []CouponLine    `json:"coupon_lines,omitempty"`
+	Refunds            []Refund        `json:"refunds,omitempty"`
+	PaymentUrl         string          `json:"payment_url,omitempty"`
+	CurrencySymbol     string          `json:"currency_symbol,omitempty"`
+	Links              Links           `json:"_links"`
+	Embedded           Embedded        `json:"_embedded,omitempty"`
+	SetPaid            bool            `json:"set_paid,omitempty"`
+	IsEditable         bool            `json:"is_editable,omitempty"`
+	NeedsPayment       bool            `json:"needs_payment,omitempty"`
+	NeedsProcessing    bool            `json:"needs_processing,omitempty"`
+	OrderType          string          `json:"order_type,omitempty"`
+
+	// Extra keeps the fields not modelled above, and Extensions those
+	// registered with RegisterExtension.
+	Extra      Extra      `json:"-"`
+	Extensions Extensions `json:"-"`
+}
+
+// UnmarshalJSON decodes o, keeping the fields it does not model in Extra
+// and Extensions, and sets the currency of its amounts.
+func (o *Order) UnmarshalJSON(data []byte) error {
+	type plain Order
+	if err := unmarshalExtensible[Order](data, (*plain)(o), &o.Extra, &o.Extensions); err != nil {
+		return err
+	}
+	setCurrency(reflect.ValueOf(o), o.Currency)
+	return nil
 }
 
+// MarshalJSON encodes o along with its Extra and Extensions fields.
+func (o Order) MarshalJSON() ([]byte, error) {
+	type plain Order
+	return marshalExtensible(plain(o), o.Extra, o.Extensions)
+}
+
+func (o *Order) extensions() *Extensions { return &o.Extensions }
+
 type WC_Paghiper_Data struct {
 	OrderTransactionDueDate   StringTime  `json:"order_transaction_due_date,omitempty"`
 	TransactionType           string      `json:"transaction_type,omitempty"`
@@ -154,22 +179,22 @@ type WC_Paghiper_Data struct {
 }
 
 type NFE struct {
-	UUID                 string     `json:"uuid,omitempty"`
-	Status               string     `json:"status,omitempty"`
-	Modelo               string     `json:"modelo,omitempty"`
-	ChaveAcesso          string     `json:"chave_acesso,omitempty"`
-	NRecibo              StringInt  `json:"n_recibo,omitempty"`
-	NNFE                 StringInt  `json:"n_nfe,omitempty"`
-	NSerie               StringOrInt  `json:"n_serie,omitempty"`
-	NFEDoc               string     `json:"nfe_doc,omitempty"`
-	PDF                  string     `json:"pdf,omitempty"`
-	URLPDF               string     `json:"url_pdf,omitempty"`
-	URLXML               string     `json:"url_xml,omitempty"`
-	URLDanfe             string     `json:"url_danfe,omitempty"`
-	URLDanfeSimplificada string     `json:"url_danfe_simplificada,omitempty"`
-	URLDanfeEtiqueta     string     `json:"url_danfe_etiqueta,omitempty"`
-	PDFRPS               string     `json:"pdf_rps,omitempty"`
-	Data                 StringTime `json:"data,omitempty"`
+	UUID                 string      `json:"uuid,omitempty"`
+	Status               string      `json:"status,omitempty"`
+	Modelo               string      `json:"modelo,omitempty"`
+	ChaveAcesso          string      `json:"chave_acesso,omitempty"`
+	NRecibo              StringInt   `json:"n_recibo,omitempty"`
+	NNFE                 StringInt   `json:"n_nfe,omitempty"`
+	NSerie               StringOrInt `json:"n_serie,omitempty"`
+	NFEDoc               string      `json:"nfe_doc,omitempty"`
+	PDF                  string      `json:"pdf,omitempty"`
+	URLPDF               string      `json:"url_pdf,omitempty"`
+	URLXML               string      `json:"url_xml,omitempty"`
+	URLDanfe             string      `json:"url_danfe,omitempty"`
+	URLDanfeSimplificada string      `json:"url_danfe_simplificada,omitempty"`
+	URLDanfeEtiqueta     string      `json:"url_danfe_etiqueta,omitempty"`
+	PDFRPS               string      `json:"pdf_rps,omitempty"`
+	Data                 StringTime  `json:"data,omitempty"`
 }
 
 type PaypalData struct {
@@ -181,21 +206,6 @@ type PaypalData struct {
 	IPNTrackingIDs []string   `json:"ipn_tracking_ids,omitempty"`
 }
 
-type Links struct {
-	Self []struct {
-		Href string `json:"href"`
-	} `json:"self"`
-	Collection []struct {
-		Href string `json:"href"`
-	} `json:"collection"`
-	Customer []struct {
-		Href string `json:"href"`
-	} `json:"customer"`
-	Up []struct {
-		Href string `json:"href"`
-	} `json:"up"`
-}
-
 type PersonType uint
 
 const (
@@ -228,14 +238,32 @@ type Billing struct {
 	BirthDate      string     `json:"birthdate,omitempty"`
 	CellPhone      string     `json:"cellphone,omitempty"`
 	Sex            string     `json:"gender,omitempty"`
-	ChurchEmail    string     `json:"church_email,omitempty"`
-	ChurchSize     StringInt  `json:"church_size,omitempty"`
 	PayerName      string     `json:"payer_name,omitempty"`
 	PayerEmail     string     `json:"payer_email,omitempty"`
 	PayerPhone     string     `json:"payer_phone,omitempty"`
 	Church         string     `json:"church,omitempty"`
+
+	// Extra keeps the fields not modelled above, and Extensions those
+	// registered with RegisterExtension.
+	Extra      Extra      `json:"-"`
+	Extensions Extensions `json:"-"`
+}
+
+// UnmarshalJSON decodes c, keeping the fields it does not model in Extra
+// and Extensions.
+func (c *Billing) UnmarshalJSON(data []byte) error {
+	type plain Billing
+	return unmarshalExtensible[Billing](data, (*plain)(c), &c.Extra, &c.Extensions)
+}
+
+// MarshalJSON encodes c along with its Extra and Extensions fields.
+func (c Billing) MarshalJSON() ([]byte, error) {
+	type plain Billing
+	return marshalExtensible(plain(c), c.Extra, c.Extensions)
 }
 
+func (c *Billing) extensions() *Extensions { return &c.Extensions }
+
 func (c *Billing) String() string {
 	res := []string{}
 	for i := 0; i < reflect.TypeOf(*c).NumField(); i++ {
@@ -265,23 +293,23 @@ type Shipping struct {
 }
 
 type LineItem struct {
-	ID          int64       `json:"id,omitempty"`
-	Name        string      `json:"name,omitempty"`
-	ProductID   int64       `json:"product_id,omitempty"`
-	VariantID   int64       `json:"variation_id,omitempty"`
-	Quantity    int         `json:"quantity,omitempty"`
-	TaxClass    string      `json:"tax_class,omitempty"`
-	SubTotal    string      `json:"subtotal,omitempty"`
-	SubtotalTax string      `json:"subtotal_tax,omitempty"`
-	Total       string      `json:"total,omitempty"`
-	TotalTax    string      `json:"total_tax,omitempty"`
-	Taxes       []TaxLine   `json:"taxes,omitempty"`
-	Meta        []MetaData  `json:"meta,omitempty"`
-	MetaData    []MetaData  `json:"meta_data,omitempty"`
-	SKU         string      `json:"sku,omitempty"`
-	Price       StringFloat `json:"price,omitempty"`
-	Image       Image       `json:"image,omitempty"`
-	ParentName  string      `json:"parent_name,omitempty"`
+	ID          int64      `json:"id,omitempty"`
+	Name        string     `json:"name,omitempty"`
+	ProductID   int64      `json:"product_id,omitempty"`
+	VariantID   int64      `json:"variation_id,omitempty"`
+	Quantity    int        `json:"quantity,omitempty"`
+	TaxClass    string     `json:"tax_class,omitempty"`
+	SubTotal    Money      `json:"subtotal,omitzero"`
+	SubtotalTax Money      `json:"subtotal_tax,omitzero"`
+	Total       Money      `json:"total,omitzero"`
+	TotalTax    Money      `json:"total_tax,omitzero"`
+	Taxes       []TaxLine  `json:"taxes,omitempty"`
+	Meta        []MetaData `json:"meta,omitempty"`
+	MetaData    []MetaData `json:"meta_data,omitempty"`
+	SKU         string     `json:"sku,omitempty"`
+	Price       Money      `json:"price,omitzero"`
+	Image       Image      `json:"image,omitempty"`
+	ParentName  string     `json:"parent_name,omitempty"`
 }
 
 func (p *PersonType) UnmarshalJSON(id []byte) error {
@@ -309,11 +337,10 @@ func (i *StringInt) UnmarshalJSON(id []byte) error {
 		*i = StringInt(0)
 		return nil
 	}
-	i_, err := strconv.Atoi(strings.Trim(strings.ReplaceAll(string(id), `"`, ""), " "))
+	// Values that are not integers decode as zero, which the drift report
+	// of WithDecodeMode lists.
+	i_, _ := strconv.Atoi(strings.Trim(strings.ReplaceAll(string(id), `"`, ""), " "))
 	*i = StringInt(i_)
-	if err != nil {
-		fmt.Printf("error parsing stringint %s: %v, setting to Zero", id, err)
-	}
 	return nil
 }
 
@@ -370,10 +397,10 @@ type TaxLine struct {
 	RateId           string     `json:"rate_id,omitempty"`
 	Label            string     `json:"label,omitempty"`
 	Compound         bool       `json:"compound,omitempty"`
-	TaxTotal         string     `json:"tax_total"`
-	ShippingTaxTotal string     `json:"shipping_tax_total,omitempty"`
-	Meta        []MetaData  `json:"meta,omitempty"`
-	MetaData    []MetaData  `json:"meta_data,omitempty"`
+	TaxTotal         Money      `json:"tax_total"`
+	ShippingTaxTotal Money      `json:"shipping_tax_total,omitzero"`
+	Meta             []MetaData `json:"meta,omitempty"`
+	MetaData         []MetaData `json:"meta_data,omitempty"`
 }
 
 type MetaData struct {
@@ -390,41 +417,41 @@ type FeeLine struct {
 	Name      string     `json:"name,omitempty"`
 	TaxClass  string     `json:"tax_class,omitempty"`
 	TaxStatus string     `json:"tax_status,omitempty"`
-	Amount    string     `json:"amount,omitempty"`
-	Total     string     `json:"total,omitempty"`
-	TotalTax  string     `json:"total_tax,omitempty"`
+	Amount    Money      `json:"amount,omitzero"`
+	Total     Money      `json:"total,omitzero"`
+	TotalTax  Money      `json:"total_tax,omitzero"`
 	Taxes     []TaxLine  `json:"taxes,omitempty"`
-	Meta        []MetaData  `json:"meta,omitempty"`
-	MetaData    []MetaData  `json:"meta_data,omitempty"`
+	Meta      []MetaData `json:"meta,omitempty"`
+	MetaData  []MetaData `json:"meta_data,omitempty"`
 }
 
 type Refund struct {
 	ID     int64  `json:"id,omitempty"`
 	Reason string `json:"refund,omitempty"`
-	Total  string `json:"total,omitempty"`
+	Total  Money  `json:"total,omitzero"`
 }
 
 type ShippingLines struct {
 	ID          int64      `json:"id,omitempty"`
 	MethodTitle string     `json:"method_title,omitempty"`
 	MethodID    string     `json:"method_id,omitempty"`
-	Total       string     `json:"total,omitempty"`
-	TotalTax    string     `json:"total_tax,omitempty"`
+	Total       Money      `json:"total,omitzero"`
+	TotalTax    Money      `json:"total_tax,omitzero"`
 	Taxes       []TaxLine  `json:"taxes,omitempty"`
-	Meta        []MetaData  `json:"meta,omitempty"`
-	MetaData    []MetaData  `json:"meta_data,omitempty"`
+	Meta        []MetaData `json:"meta,omitempty"`
+	MetaData    []MetaData `json:"meta_data,omitempty"`
 }
 
 type CouponLine struct {
-	ID           int64      `json:"id,omitempty"`
-	Code         string     `json:"code,omitempty"`
-	Discount     string     `json:"discount,omitempty"`
-	DiscountTax  string     `json:"discount_tax,omitempty"`
-	NominalAmount float64    `json:"nominal_amount,omitempty"`
+	ID            int64      `json:"id,omitempty"`
+	Code          string     `json:"code,omitempty"`
+	Discount      Money      `json:"discount,omitzero"`
+	DiscountTax   Money      `json:"discount_tax,omitzero"`
+	NominalAmount Money      `json:"nominal_amount,omitzero"`
 	FreeShipping  bool       `json:"free_shipping,omitempty"`
-	Meta         []MetaData `json:"meta,omitempty"`
-	MetaData     []MetaData `json:"meta_data,omitempty"`
-	DiscountType string     `json:"discount_type,omitempty"`
+	Meta          []MetaData `json:"meta,omitempty"`
+	MetaData      []MetaData `json:"meta_data,omitempty"`
+	DiscountType  string     `json:"discount_type,omitempty"`
 }
 
 func GetMetaData(md []MetaData, key string) (interface{}, error) {
@@ -435,63 +462,3 @@ func GetMetaData(md []MetaData, key string) (interface{}, error) {
 	}
 	return nil, fmt.Errorf("meta data key %s not found", key)
 }
-
-func (o *OrderServiceOp) List(options interface{}) ([]Order, error) {
-	orders, _, err := o.ListWithPagination(options)
-	return orders, err
-}
-
-// ListWithPagination lists products and return pagination to retrieve next/previous results.
-func (o *OrderServiceOp) ListWithPagination(options interface{}) ([]Order, *Pagination, error) {
-	path := ordersBasePath
-	resource := make([]Order, 0)
-	// headers := http.Header{}
-	headers, err := o.client.createAndDoGetHeaders("GET", path, nil, options, &resource)
-	if err != nil {
-		return nil, nil, err
-	}
-	// Extract pagination info from header
-	pagination, err := extractPagination(headers)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return resource, pagination, err
-}
-
-func (o *OrderServiceOp) Create(order Order) (*Order, error) {
-	path := ordersBasePath
-	resource := new(Order)
-
-	err := o.client.Post(path, order, &resource)
-	return resource, err
-}
-
-// Get individual order
-func (o *OrderServiceOp) Get(orderID int64, options interface{}) (*Order, error) {
-	path := fmt.Sprintf("%s/%d", ordersBasePath, orderID)
-	resource := new(Order)
-	err := o.client.Get(path, resource, options)
-	return resource, err
-}
-
-func (o *OrderServiceOp) Update(order *Order) (*Order, error) {
-	path := fmt.Sprintf("%s/%d", ordersBasePath, order.ID)
-	resource := new(Order)
-	err := o.client.Put(path, order, &resource)
-	return resource, err
-}
-
-func (o *OrderServiceOp) Delete(orderID int64, options interface{}) (*Order, error) {
-	path := fmt.Sprintf("%s/%d", ordersBasePath, orderID)
-	resource := new(Order)
-	err := o.client.Delete(path, options, &resource)
-	return resource, err
-}
-
-func (o *OrderServiceOp) Batch(data OrderBatchOption) (*OrderBatchResource, error) {
-	path := fmt.Sprintf("%s/batch", ordersBasePath)
-	resource := new(OrderBatchResource)
-	err := o.client.Post(path, data, &resource)
-	return resource, err
-}
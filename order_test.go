@@ -67,8 +67,8 @@ func initOrder() Order {
 			{
 				Name:      "北京烤鸭" + timeNowStr,
 				ProductID: 10,
-				SubTotal:  "56.00",
-				Total:     "56.00",
+				SubTotal:  MustParseMoney("56.00"),
+				Total:     MustParseMoney("56.00"),
 				Quantity:  2,
 				MetaData: []MetaData{
 					{
@@ -77,7 +77,7 @@ func initOrder() Order {
 					},
 				},
 				SKU:   "wutongshan_001" + timeNowStr,
-				Price: 56.00,
+				Price: MustParseMoney("56.00"),
 			},
 		},
 	}
@@ -129,8 +129,8 @@ func TestOrderServiceOp_Batch(t *testing.T) {
 		Update: []Order{
 			{
 				ID:       17,
-				TotalTax: ParseStringFloat("20.00"),
-				Total:    ParseStringFloat("120"),
+				TotalTax: MustParseMoney("20.00"),
+				Total:    MustParseMoney("120"),
 			},
 		},
 		Delete: []int64{
@@ -0,0 +1,319 @@
+package woocommerce
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+// PageFunc fetches one page of a list, such as the ListWithPaginationWithContext
+// method of the services.
+type PageFunc[T any] func(ctx context.Context, options interface{}) ([]T, *Pagination, error)
+
+// Pager walks every page of a list, sending the original options, filters
+// included, with each page request.
+//
+//	pager := woocommerce.NewPager(ctx, client.Order.ListWithPaginationWithContext,
+//		woocommerce.OrderListOption{Status: []string{"processing"}})
+//	for order, err := range pager.All() {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// The services' Pager method does the same with typed options:
+//
+//	pager := client.Order.Pager(ctx, woocommerce.OrderListOption{Status: []string{"processing"}})
+type Pager[T any] struct {
+	ctx     context.Context
+	fetch   PageFunc[T]
+	options interface{}
+
+	mu         sync.Mutex
+	total      uint64
+	totalPages uint64
+}
+
+// NewPager returns a Pager listing with fetch from the page set in options,
+// or the first one. options is a list option struct, such as OrderListOption,
+// or url.Values.
+func NewPager[T any](ctx context.Context, fetch PageFunc[T], options interface{}) *Pager[T] {
+	return &Pager[T]{ctx: ctx, fetch: fetch, options: options}
+}
+
+// Total returns the number of items reported by the store in X-WP-Total,
+// available once the first page was fetched.
+func (p *Pager[T]) Total() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.total
+}
+
+// TotalPages returns the number of pages reported by the store in
+// X-WP-TotalPages, available once the first page was fetched.
+func (p *Pager[T]) TotalPages() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.totalPages
+}
+
+// All returns an iterator over the items of every page. It stops after
+// yielding an error, including the context's error once it is done.
+func (p *Pager[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		values, err := optionValues(p.options)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		page := 1
+		if v := values.Get("page"); v != "" {
+			if page, err = strconv.Atoi(v); err != nil {
+				yield(zero, err)
+				return
+			}
+		}
+
+		for {
+			if err := p.ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			values.Set("page", strconv.Itoa(page))
+			items, pagination, err := p.fetch(p.ctx, values)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if pagination != nil {
+				p.mu.Lock()
+				p.total, p.totalPages = pagination.Total, pagination.TotalPages
+				p.mu.Unlock()
+			}
+			for _, item := range items {
+				if err := p.ctx.Err(); err != nil {
+					yield(zero, err)
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			next := nextPage(page, pagination)
+			if len(items) == 0 || next <= page {
+				return
+			}
+			page = next
+		}
+	}
+}
+
+// maxPageRateLimitRetries bounds how many times FetchAll retries a page
+// rejected with 429 once the client's own retries are exhausted.
+const maxPageRateLimitRetries = 5
+
+// FetchAll fetches every page and returns their items in order. The first
+// page tells how many pages there are; the others are then fetched with up to
+// concurrency requests in flight. A page rejected with 429 pauses every worker
+// for the Retry-After delay before being fetched again.
+//
+// Items created or deleted while paging shift others across pages, so items
+// already seen on an earlier page, as told by id, are dropped, and pages past
+// the initial count are followed for as long as the store reports more.
+func (p *Pager[T]) FetchAll(concurrency int, id func(T) int64) ([]T, error) {
+	values, err := optionValues(p.options)
+	if err != nil {
+		return nil, err
+	}
+	first := 1
+	if v := values.Get("page"); v != "" {
+		if first, err = strconv.Atoi(v); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(p.ctx)
+	defer cancel()
+	gate := &rateLimitGate{}
+	calls := newCallGroup(ctx)
+	defer calls.done()
+
+	items, pagination, err := p.fetchPage(calls.context(ctx), gate, values, first)
+	if err != nil {
+		return nil, err
+	}
+	pages := [][]T{items}
+	last := first
+	if pagination != nil && pagination.TotalPages > uint64(first) {
+		last = int(pagination.TotalPages)
+	}
+
+	if last > first {
+		rest := make([][]T, last-first)
+		paginations := make([]*Pagination, last-first)
+		jobs := make(chan int)
+		var wg sync.WaitGroup
+		var once sync.Once
+		var firstErr error
+		for range max(concurrency, 1) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for page := range jobs {
+					items, pagination, err := p.fetchPage(calls.context(ctx), gate, values, page)
+					if err != nil {
+						once.Do(func() {
+							firstErr = err
+							cancel()
+						})
+						continue
+					}
+					rest[page-first-1], paginations[page-first-1] = items, pagination
+				}
+			}()
+		}
+	feed:
+		for page := first + 1; page <= last; page++ {
+			select {
+			case jobs <- page:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+		close(jobs)
+		wg.Wait()
+		if firstErr != nil {
+			return nil, firstErr
+		}
+		if err := p.ctx.Err(); err != nil {
+			return nil, err
+		}
+		pages = append(pages, rest...)
+		pagination = paginations[len(paginations)-1]
+	}
+
+	// Items created meanwhile may have pushed others past the last page.
+	for len(pages[len(pages)-1]) > 0 {
+		next := nextPage(last, pagination)
+		if next <= last {
+			break
+		}
+		last = next
+		items, pagination, err = p.fetchPage(calls.context(ctx), gate, values, last)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, items)
+	}
+
+	seen := map[int64]bool{}
+	var all []T
+	for _, page := range pages {
+		for _, item := range page {
+			if key := id(item); !seen[key] {
+				seen[key] = true
+				all = append(all, item)
+			}
+		}
+	}
+	return all, nil
+}
+
+// fetchPage fetches page with its own copy of values, waiting for and
+// retrying on rate limit responses.
+func (p *Pager[T]) fetchPage(ctx context.Context, gate *rateLimitGate, values url.Values, page int) ([]T, *Pagination, error) {
+	query, _ := optionValues(values)
+	query.Set("page", strconv.Itoa(page))
+	for retries := 0; ; retries++ {
+		if err := gate.wait(ctx); err != nil {
+			return nil, nil, err
+		}
+		items, pagination, err := p.fetch(ctx, query)
+		if err == nil {
+			if pagination != nil {
+				p.mu.Lock()
+				p.total, p.totalPages = pagination.Total, pagination.TotalPages
+				p.mu.Unlock()
+			}
+			return items, pagination, nil
+		}
+		retryAfter, limited := rateLimited(err)
+		if !limited || retries >= maxPageRateLimitRetries {
+			return nil, nil, err
+		}
+		gate.pause(retryAfter)
+	}
+}
+
+// rateLimited reports whether err is a 429 response, and the delay it asked for.
+func rateLimited(err error) (time.Duration, bool) {
+	var rateErr RateLimitError
+	if errors.As(err, &rateErr) {
+		return time.Duration(rateErr.RetryAfter) * time.Second, true
+	}
+	return 0, errors.Is(err, ErrRateLimited)
+}
+
+// rateLimitGate holds back the workers of FetchAll while the store is rate
+// limiting them.
+type rateLimitGate struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+func (g *rateLimitGate) pause(d time.Duration) {
+	if d <= 0 {
+		d = time.Second
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if until := time.Now().Add(d); until.After(g.until) {
+		g.until = until
+	}
+}
+
+func (g *rateLimitGate) wait(ctx context.Context) error {
+	g.mu.Lock()
+	d := time.Until(g.until)
+	g.mu.Unlock()
+	return sleepContext(ctx, d)
+}
+
+// nextPage returns the page following page, or 0 if it was the last one.
+func nextPage(page int, pagination *Pagination) int {
+	if pagination == nil {
+		return 0
+	}
+	if pagination.NextPageOptions != nil && pagination.NextPageOptions.Page > 0 {
+		return pagination.NextPageOptions.Page
+	}
+	if uint64(page) < pagination.TotalPages {
+		return page + 1
+	}
+	return 0
+}
+
+// optionValues returns a copy of options as url.Values.
+func optionValues(options interface{}) (url.Values, error) {
+	switch o := options.(type) {
+	case nil:
+		return url.Values{}, nil
+	case url.Values:
+		values := url.Values{}
+		for k, v := range o {
+			values[k] = append([]string(nil), v...)
+		}
+		return values, nil
+	default:
+		return query.Values(options)
+	}
+}
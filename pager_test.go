@@ -0,0 +1,178 @@
+package woocommerce
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// pagedOrders serves 5 orders, 2 per page, with WooCommerce style headers.
+func pagedOrders(queries *[]url.Values) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		*queries = append(*queries, q)
+		page, _ := strconv.Atoi(q.Get("page"))
+		w.Header().Set("X-WP-Total", "5")
+		w.Header().Set("X-WP-TotalPages", "3")
+		if page < 3 {
+			next := url.Values{"page": {strconv.Itoa(page + 1)}, "per_page": {"2"}}
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?%s>; rel="next"`, r.Host, r.URL.Path, next.Encode()))
+		}
+		first := (page-1)*2 + 1
+		last := min(first+1, 5)
+		fmt.Fprint(w, "[")
+		for id := first; id <= last; id++ {
+			if id > first {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"id":%d}`, id)
+		}
+		fmt.Fprint(w, "]")
+	})
+}
+
+func TestPager_All(t *testing.T) {
+	var queries []url.Values
+	c := newTestClient(t, pagedOrders(&queries))
+
+	opts := OrderListOption{Status: []string{"processing", "on-hold"}, Customer: 7}
+	opts.PerPage = 2
+	pager := NewPager(context.Background(), c.Order.ListWithPaginationWithContext, opts)
+
+	var ids []int64
+	for order, err := range pager.All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, order.ID)
+	}
+	if fmt.Sprint(ids) != "[1 2 3 4 5]" {
+		t.Errorf("ids = %v", ids)
+	}
+	if pager.Total() != 5 || pager.TotalPages() != 3 {
+		t.Errorf("total %d, pages %d", pager.Total(), pager.TotalPages())
+	}
+	if len(queries) != 3 {
+		t.Fatalf("%d requests, want 3", len(queries))
+	}
+	for i, q := range queries {
+		if q.Get("page") != strconv.Itoa(i+1) || q.Get("per_page") != "2" || q.Get("customer") != "7" || len(q["status"]) != 2 {
+			t.Errorf("page %d lost options: %v", i+1, q)
+		}
+	}
+}
+
+func TestPager_StopsOnCancel(t *testing.T) {
+	var queries []url.Values
+	c := newTestClient(t, pagedOrders(&queries))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pager := NewPager(ctx, c.Order.ListWithPaginationWithContext, nil)
+
+	var n int
+	var lastErr error
+	for _, err := range pager.All() {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		n++
+		cancel()
+	}
+	if n != 1 || !errors.Is(lastErr, context.Canceled) || len(queries) != 1 {
+		t.Errorf("got %d items, %d requests, err %v", n, len(queries), lastErr)
+	}
+}
+
+func TestExtractPagination(t *testing.T) {
+	h := http.Header{}
+	h.Set("X-WP-Total", "40")
+	h.Set("X-WP-TotalPages", "4")
+	h.Set("Link", `<https://shop.example.com/wp-json/wc/v3/orders?page=1&per_page=10>; rel="prev", <https://shop.example.com/wp-json/wc/v3/orders?page=3&per_page=10&status=processing>; rel="next"`)
+	p, err := extractPagination(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.NextPageOptions.Page != 3 || p.NextPageOptions.PerPage != 10 || p.PreviousPageOptions.Page != 1 || p.Total != 40 {
+		t.Errorf("unexpected pagination %+v", p)
+	}
+
+	h.Set("Link", "garbage")
+	if _, err := extractPagination(h); err == nil {
+		t.Error("expected an error for a malformed Link header")
+	}
+}
+
+func TestPager_FetchAll(t *testing.T) {
+	var mu sync.Mutex
+	var queries []url.Values
+	inner := pagedOrders(&queries)
+	limited := false
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		// Page 2 is rate limited once.
+		if r.URL.Query().Get("page") == "2" && !limited {
+			limited = true
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		inner.ServeHTTP(w, r)
+	}))
+
+	pager := NewPager(context.Background(), c.Order.ListWithPaginationWithContext, OrderListOption{Customer: 7})
+	orders, err := pager.FetchAll(4, func(o Order) int64 { return o.ID })
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []int64
+	for _, o := range orders {
+		ids = append(ids, o.ID)
+	}
+	if fmt.Sprint(ids) != "[1 2 3 4 5]" {
+		t.Errorf("ids = %v", ids)
+	}
+	if len(queries) != 3 || !limited {
+		t.Errorf("%d pages served, rate limited %v", len(queries), limited)
+	}
+	for _, q := range queries {
+		if q.Get("customer") != "7" {
+			t.Errorf("filter lost: %v", q)
+		}
+	}
+}
+
+func TestPager_FetchAllShiftedPages(t *testing.T) {
+	// An order created after the first page was read pushes order 2 onto
+	// page 2 and order 4 onto a page 3 that did not exist at first.
+	pages := map[string]string{
+		"1": `[{"id":1},{"id":2}]`,
+		"2": `[{"id":2},{"id":3}]`,
+		"3": `[{"id":4}]`,
+	}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "1" {
+			w.Header().Set("X-WP-TotalPages", "2")
+		} else {
+			w.Header().Set("X-WP-TotalPages", "3")
+		}
+		fmt.Fprint(w, pages[page])
+	}))
+
+	orders, err := NewPager(context.Background(), c.Order.ListWithPaginationWithContext, nil).
+		FetchAll(2, func(o Order) int64 { return o.ID })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 4 || orders[2].ID != 3 || orders[3].ID != 4 {
+		t.Errorf("orders = %+v", orders)
+	}
+}
@@ -0,0 +1,107 @@
+package woocommerce
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Patch updates only the given top level JSON fields of the object with the
+// ID of item, leaving the others as stored. Unlike Update, the fields are
+// sent even when they are zero, so they can be cleared:
+//
+//	zero := 0
+//	product.Featured = false
+//	product.StockQuantity = &zero
+//	product.SalePrice = &Money{} // ""
+//	updated, err := client.Product.Patch(product, "featured", "stock_quantity", "sale_price")
+//
+// Nil slices are sent as empty arrays, nil pointers and unset dates as null,
+// and unset amounts as "", which is how WooCommerce clears a price.
+// Fields the type does not model are taken from its Extensions and Extra.
+func (r *Resource[T, ListOpt]) Patch(item *T, fields ...string) (*T, error) {
+	return r.PatchWithContext(context.Background(), item, fields...)
+}
+
+// PatchWithContext is like Patch but bound to ctx.
+func (r *Resource[T, ListOpt]) PatchWithContext(ctx context.Context, item *T, fields ...string) (*T, error) {
+	body, err := patchBody(item, fields, r.client.StoreLocation())
+	if err != nil {
+		return nil, err
+	}
+	updated := new(T)
+	if err := r.client.PutWithContext(ctx, r.itemPath(r.id(item)), body, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// patchBody returns the JSON object of the fields of item, each encoded even
+// when it is zero, with its local dates in loc.
+func patchBody[T any](item *T, fields []string, loc *time.Location) (map[string]json.RawMessage, error) {
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("woocommerce: no fields to patch")
+	}
+	v := *item
+	utcTimes(&v)
+	storeTimes(&v, loc)
+	var full map[string]json.RawMessage
+	body := make(map[string]json.RawMessage, len(fields))
+	for _, name := range fields {
+		field, ok := jsonField(reflect.ValueOf(&v).Elem(), name)
+		if !ok {
+			// Not modelled, so it can only be an extension or extra field.
+			if full == nil {
+				data, err := json.Marshal(v)
+				if err != nil {
+					return nil, err
+				}
+				if err := json.Unmarshal(data, &full); err != nil {
+					return nil, err
+				}
+			}
+			raw, ok := full[name]
+			if !ok {
+				return nil, fmt.Errorf("woocommerce: %T has no field %q to patch", item, name)
+			}
+			body[name] = raw
+			continue
+		}
+		if field.Kind() == reflect.Slice && field.IsNil() {
+			body[name] = json.RawMessage("[]")
+			continue
+		}
+		raw, err := json.Marshal(field.Addr().Interface())
+		if err != nil {
+			return nil, fmt.Errorf("woocommerce: encoding %q: %w", name, err)
+		}
+		body[name] = raw
+	}
+	return body, nil
+}
+
+// jsonField returns the field of struct v named name in JSON, looking into
+// embedded structs.
+func jsonField(v reflect.Value, name string) (reflect.Value, bool) {
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	t := v.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch {
+		case tag == "-" || (!f.IsExported() && !f.Anonymous):
+		case tag == "" && f.Anonymous:
+			if field, ok := jsonField(reflect.Indirect(v.Field(i)), name); ok {
+				return field, true
+			}
+		case tag == name || (tag == "" && f.Name == name):
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
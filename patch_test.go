@@ -0,0 +1,92 @@
+package woocommerce
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestResource_Patch(t *testing.T) {
+	var method, path string
+	var sent map[string]json.RawMessage
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		sent = nil
+		if err := json.Unmarshal(body, &sent); err != nil {
+			t.Errorf("invalid body %s: %v", body, err)
+		}
+		io.WriteString(w, `{"id":7,"featured":false,"stock_quantity":0}`)
+	}))
+	ctx := context.Background()
+
+	zero := 0
+	product := &Product{ID: 7, Name: "Mug", Featured: false, StockQuantity: &zero, SalePrice: &Money{}}
+	SetExtension(product, ExtNFE, &ProductNFE{CodigoNcm: "6912"})
+	product.Extra.Set("brand", "acme")
+	updated, err := c.Product.PatchWithContext(ctx, product, "featured", "stock_quantity", "sale_price", "categories", "nfe", "brand")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.ID != 7 || method != http.MethodPut || path != "/wp-json/wc/v3/products/7" {
+		t.Errorf("%s %s returned %+v", method, path, updated)
+	}
+	want := map[string]string{
+		"featured":       "false",
+		"stock_quantity": "0",
+		"sale_price":     `""`,
+		"categories":     "[]",
+		"brand":          `"acme"`,
+	}
+	if len(sent) != len(want)+1 {
+		t.Errorf("sent %d fields, want %d: %v", len(sent), len(want)+1, sent)
+	}
+	for field, value := range want {
+		if got := string(sent[field]); got != value {
+			t.Errorf("%s = %s, want %s", field, got, value)
+		}
+	}
+	var nfe ProductNFE
+	if err := json.Unmarshal(sent["nfe"], &nfe); err != nil || nfe.CodigoNcm != "6912" {
+		t.Errorf("nfe = %s", sent["nfe"])
+	}
+
+	coupon := &Coupon{ID: 3}
+	if _, err := c.Coupon.PatchWithContext(ctx, coupon, "product_ids", "date_expires"); err != nil {
+		t.Fatal(err)
+	}
+	if string(sent["product_ids"]) != "[]" || string(sent["date_expires"]) != "null" {
+		t.Errorf("coupon patch sent %v", sent)
+	}
+
+	if _, err := c.Order.PatchWithContext(ctx, &Order{ID: 1}, "no_such_field"); err == nil {
+		t.Error("expected an error patching an unknown field")
+	}
+	if _, err := c.Order.PatchWithContext(ctx, &Order{ID: 1}); err == nil {
+		t.Error("expected an error patching no fields")
+	}
+}
+
+func TestJSONField(t *testing.T) {
+	type base struct {
+		ID int64 `json:"id"`
+	}
+	type item struct {
+		*base
+		Name string `json:"name,omitempty"`
+		Skip string `json:"-"`
+	}
+	v := item{base: &base{ID: 4}}
+	if f, ok := jsonField(reflect.ValueOf(&v).Elem(), "id"); !ok || f.Int() != 4 {
+		t.Errorf("id = %v, %v", f, ok)
+	}
+	if _, ok := jsonField(reflect.ValueOf(&v).Elem(), "Skip"); ok {
+		t.Error("found a field tagged -")
+	}
+	if _, ok := jsonField(reflect.ValueOf(&item{}).Elem(), "id"); ok {
+		t.Error("found a field of a nil embedded struct")
+	}
+}
@@ -1,6 +1,9 @@
 package woocommerce
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 const (
 	paymentGatewayBasePath = "payment_gateways"
@@ -9,9 +12,14 @@ const (
 // PaymentGatewayService is an interface for interfacing with the payment-gateways endpoints of woocommerce API
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#payment-gateways
 type PaymentGatewayService interface {
-	Get(id string) (*PaymentGateway, error)
+	Get(id string, options ...GetOption) (*PaymentGateway, error)
 	List(options interface{}) ([]PaymentGateway, error)
 	Update(pg *PaymentGateway) (*PaymentGateway, error)
+	Patch(pg *PaymentGateway, fields ...string) (*PaymentGateway, error)
+	GetWithContext(ctx context.Context, id string, options ...GetOption) (*PaymentGateway, error)
+	ListWithContext(ctx context.Context, options interface{}) ([]PaymentGateway, error)
+	UpdateWithContext(ctx context.Context, pg *PaymentGateway) (*PaymentGateway, error)
+	PatchWithContext(ctx context.Context, pg *PaymentGateway, fields ...string) (*PaymentGateway, error)
 }
 
 // PaymentGatewayServiceOp handles communication with the payment gateway related methods of WooCommerce restful api
@@ -32,6 +40,21 @@ type PaymentGateway struct {
 	MethodSupports    []string `json:"method_supports,omitempty"`
 	Settings          *Setting `json:"settings,omitempty"`
 	Links             Links    `json:"_links,omitempty"`
+
+	// Extra keeps the fields not modelled above, such as those of plugins.
+	Extra Extra `json:"-"`
+}
+
+// UnmarshalJSON decodes g, keeping the fields it does not model in Extra.
+func (g *PaymentGateway) UnmarshalJSON(data []byte) error {
+	type plain PaymentGateway
+	return unmarshalExtra(data, (*plain)(g), &g.Extra)
+}
+
+// MarshalJSON encodes g along with its Extra fields.
+func (g PaymentGateway) MarshalJSON() ([]byte, error) {
+	type plain PaymentGateway
+	return marshalExtra(plain(g), g.Extra)
 }
 
 type Setting struct {
@@ -78,27 +101,68 @@ type PaymentSetting struct {
 // List return multiple payment gateway
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#list-all-payment-gateways
 func (p *PaymentGatewayServiceOp) List(options interface{}) ([]PaymentGateway, error) {
+	return p.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext is like List but bound to ctx.
+func (p *PaymentGatewayServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]PaymentGateway, error) {
 	path := fmt.Sprintf("%s", paymentGatewayBasePath)
 	resource := make([]PaymentGateway, 0)
-	err := p.client.Get(path, &resource, options)
-	return resource, err
+	if err := p.client.GetWithContext(ctx, path, &resource, options); err != nil {
+		return nil, err
+	}
+	return resource, nil
 }
 
-// Get implement for retrieve and view a specific payment gateway
+// Get implement for retrieve and view a specific payment gateway, with the
+// first of options, if any.
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#retrieve-an-payment-gateway
-func (p *PaymentGatewayServiceOp) Get(id string) (*PaymentGateway, error) {
+func (p *PaymentGatewayServiceOp) Get(id string, options ...GetOption) (*PaymentGateway, error) {
+	return p.GetWithContext(context.Background(), id, options...)
+}
+
+// GetWithContext is like Get but bound to ctx.
+func (p *PaymentGatewayServiceOp) GetWithContext(ctx context.Context, id string, options ...GetOption) (*PaymentGateway, error) {
 	path := fmt.Sprintf("%s/%s", paymentGatewayBasePath, id)
 	resource := new(PaymentGateway)
-	err := p.client.Get(path, &resource, nil)
-	return resource, err
+	if err := p.client.GetWithContext(ctx, path, resource, firstGetOption(options)); err != nil {
+		return nil, err
+	}
+	return resource, nil
 }
 
 // Update method allow you to make changes to a payment gateway
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#update-a-payment-gateway
 func (p *PaymentGatewayServiceOp) Update(pg *PaymentGateway) (*PaymentGateway, error) {
+	return p.UpdateWithContext(context.Background(), pg)
+}
+
+// UpdateWithContext is like Update but bound to ctx.
+func (p *PaymentGatewayServiceOp) UpdateWithContext(ctx context.Context, pg *PaymentGateway) (*PaymentGateway, error) {
 	path := fmt.Sprintf("%s/%s", paymentGatewayBasePath, pg.ID)
 	resource := new(PaymentGateway)
-	err := p.client.Put(path, pg, &resource)
+	if err := p.client.PutWithContext(ctx, path, pg, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
 
-	return resource, err
+// Patch updates only the given fields of the gateway with the ID of pg, see
+// Resource.Patch.
+func (p *PaymentGatewayServiceOp) Patch(pg *PaymentGateway, fields ...string) (*PaymentGateway, error) {
+	return p.PatchWithContext(context.Background(), pg, fields...)
+}
+
+// PatchWithContext is like Patch but bound to ctx.
+func (p *PaymentGatewayServiceOp) PatchWithContext(ctx context.Context, pg *PaymentGateway, fields ...string) (*PaymentGateway, error) {
+	body, err := patchBody(pg, fields, p.client.StoreLocation())
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s", paymentGatewayBasePath, pg.ID)
+	resource := new(PaymentGateway)
+	if err := p.client.PutWithContext(ctx, path, body, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
 }
@@ -1,6 +1,7 @@
 package woocommerce
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -11,7 +12,11 @@ import (
 	"time"
 )
 
-var linkRegex = regexp.MustCompile(`^ *<([^>]+)>; rel="(prev|next|first|last)" *$`)
+var (
+	// linkRegex matches each `<url>; params` entry of a Link header
+	linkRegex    = regexp.MustCompile(`<([^>]*)>([^,<]*)`)
+	linkRelRegex = regexp.MustCompile(`;\s*rel="?([^";]+)"?`)
+)
 
 // ProductService allows you to create, view, update, and delete individual, or a batch, of products
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#products
@@ -21,99 +26,131 @@ type ProductService interface {
 	List(options interface{}) ([]Product, error)
 	ListWithPagination(options interface{}) ([]Product, *Pagination, error)
 	Update(product *Product) (*Product, error)
+	Patch(product *Product, fields ...string) (*Product, error)
 	Delete(productID int64, options interface{}) (*Product, error)
 	Batch(option ProductBatchOption) (*ProductBatchResource, error)
 	ListVariations(productID int64, options interface{}) ([]Product, error)
+	CreateWithContext(ctx context.Context, product Product) (*Product, error)
+	GetWithContext(ctx context.Context, productID int64, options interface{}) (*Product, error)
+	ListWithContext(ctx context.Context, options interface{}) ([]Product, error)
+	ListWithPaginationWithContext(ctx context.Context, options interface{}) ([]Product, *Pagination, error)
+	UpdateWithContext(ctx context.Context, product *Product) (*Product, error)
+	PatchWithContext(ctx context.Context, product *Product, fields ...string) (*Product, error)
+	DeleteWithContext(ctx context.Context, productID int64, options interface{}) (*Product, error)
+	BatchWithContext(ctx context.Context, option ProductBatchOption) (*ProductBatchResource, error)
+	ListVariationsWithContext(ctx context.Context, productID int64, options interface{}) ([]Product, error)
+	Pager(ctx context.Context, options ProductListOptions) *Pager[Product]
+	Base() *Resource[Product, ProductListOptions]
 }
 
 // Product represent WooCommerce Product
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#product-properties
 type Product struct {
-	ID                int64              `json:"id"`
-	Name              string             `json:"name"`
-	Slug              string             `json:"slug"`
-	Permalink         string             `json:"permalink"`
-	DateCreated       StringTime         `json:"date_created,omitempty"`
-	DateCreatedGmt    StringTime         `json:"date_created_gmt,omitempty"`
-	DateModified      StringTime         `json:"date_modified,omitempty"`
-	DateModifiedGmt   StringTime         `json:"date_modified_gmt,omitempty"`
-	Type              string             `json:"type"`
-	Status            string             `json:"status"`
-	Featured          bool               `json:"featured"`
-	CatalogVisibility string             `json:"catalog_visibility"`
-	Description       string             `json:"description"`
-	ShortDescription  string             `json:"short_description"`
-	SKU               string             `json:"sku"`
-	GlobalUniqueID    string             `json:"global_unique_id"`
-	Price             *StringFloat       `json:"price"`
-	RegularPrice      *StringFloat       `json:"regular_price"`
-	SalePrice         *StringFloat       `json:"sale_price"`
-	DateOnSaleFrom    StringTime         `json:"date_on_sale_from"`
-	DateOnSaleFromGmt StringTime         `json:"date_on_sale_from_gmt"`
-	DateOnSaleTo      StringTime         `json:"date_on_sale_to"`
-	DateOnSaleToGmt   StringTime         `json:"date_on_sale_to_gmt"`
-	PriceHTML         string             `json:"price_html"`
-	OnSale            bool               `json:"on_sale"`
-	Purchasable       bool               `json:"purchasable"`
-	TotalSales        StringFloat        `json:"total_sales"`
-	Virtual           bool               `json:"virtual"`
-	Visible           bool               `json:"visible"`
-	Downloadable      bool               `json:"downloadable"`
-	Downloads         []Download         `json:"downloads"`
-	DownloadLimit     int                `json:"download_limit"`
-	DownloadExpiry    int                `json:"download_expiry"`
-	ExternalURL       string             `json:"external_url"`
-	ButtonText        string             `json:"button_text"`
-	TaxStatus         string             `json:"tax_status"`
-	TaxClass          string             `json:"tax_class"`
-	ManageStock       bool               `json:"manage_stock"`
-	StockQuantity     *int               `json:"stock_quantity"`
-	StockStatus       string             `json:"stock_status"`
-	InStock           bool               `json:"in_stock"`
-	Backorders        string             `json:"backorders"`
-	BackordersAllowed bool               `json:"backorders_allowed"`
-	Backordered       bool               `json:"backordered"`
-	SoldIndividually  bool               `json:"sold_individually"`
-	Weight            string             `json:"weight"`
-	Dimensions        Dimensions         `json:"dimensions"`
-	ShippingRequired  bool               `json:"shipping_required"`
-	ShippingTaxable   bool               `json:"shipping_taxable"`
-	ShippingClass     string             `json:"shipping_class"`
-	ShippingClassId   int                `json:"shipping_class_id"`
-	ReviewsAllowed    bool               `json:"reviews_allowed"`
-	AverageRating     string             `json:"average_rating"`
-	RatingCounts      interface{}        `json:"rating_counts"`
-	ReviewCount       int                `json:"review_count"`
-	RatingCount       int                `json:"rating_count"`
-	RelatedIds        []int              `json:"related_ids"`
-	UpsellIds         []int              `json:"upsell_ids"`
-	CrossSellIds      []int              `json:"cross_sell_ids"`
-	ParentId          int                `json:"parent_id"`
-	PurchaseNote      string             `json:"purchase_note"`
-	LowStockAmount    int                `json:"low_stock_amount"`
-	Categories        []ProductCategory  `json:"categories"`
-	CategoryIds       []int              `json:"category_ids"`
-	Tags              []ProductTag       `json:"tags"`
-	TagIds            []int              `json:"tag_ids"`
-	Image             *ProductImage      `json:"image"`
-	Images            []ProductImage     `json:"images"`
-	Attributes        []ProductAttribute `json:"attributes"`
-	DefaultAttributes []ProductAttribute `json:"default_attributes"`
-	Variations        []int              `json:"variations"`
-	GroupedProducts   []int              `json:"grouped_products"`
-	MenuOrder         int                `json:"menu_order"`
-	PostPassword      string             `json:"post_password"`
-	ImageID           string             `json:"image_id"`
-	GalleryImageIDs   []int              `json:"gallery_image_ids"`
-	MetaData          []MetaDatum        `json:"meta"`
-	MetaDataList      []MetaDatum        `json:"meta_data,omitempty"`
-	DownloadType                        string             `json:"download_type"`
-	HasOptions                          bool               `json:"has_options"`
+	ID                                    int64                  `json:"id"`
+	Name                                  string                 `json:"name"`
+	Slug                                  string                 `json:"slug"`
+	Permalink                             string                 `json:"permalink"`
+	DateCreated                           StringTime             `json:"date_created,omitempty"`
+	DateCreatedGmt                        StringTime             `json:"date_created_gmt,omitempty"`
+	DateModified                          StringTime             `json:"date_modified,omitempty"`
+	DateModifiedGmt                       StringTime             `json:"date_modified_gmt,omitempty"`
+	Type                                  string                 `json:"type"`
+	Status                                string                 `json:"status"`
+	Featured                              bool                   `json:"featured"`
+	CatalogVisibility                     string                 `json:"catalog_visibility"`
+	Description                           string                 `json:"description"`
+	ShortDescription                      string                 `json:"short_description"`
+	SKU                                   string                 `json:"sku"`
+	GlobalUniqueID                        string                 `json:"global_unique_id"`
+	Price                                 *Money                 `json:"price"`
+	RegularPrice                          *Money                 `json:"regular_price"`
+	SalePrice                             *Money                 `json:"sale_price"`
+	DateOnSaleFrom                        StringTime             `json:"date_on_sale_from"`
+	DateOnSaleFromGmt                     StringTime             `json:"date_on_sale_from_gmt"`
+	DateOnSaleTo                          StringTime             `json:"date_on_sale_to"`
+	DateOnSaleToGmt                       StringTime             `json:"date_on_sale_to_gmt"`
+	PriceHTML                             string                 `json:"price_html"`
+	OnSale                                bool                   `json:"on_sale"`
+	Purchasable                           bool                   `json:"purchasable"`
+	TotalSales                            StringFloat            `json:"total_sales"`
+	Virtual                               bool                   `json:"virtual"`
+	Visible                               bool                   `json:"visible"`
+	Downloadable                          bool                   `json:"downloadable"`
+	Downloads                             []Download             `json:"downloads"`
+	DownloadLimit                         int                    `json:"download_limit"`
+	DownloadExpiry                        int                    `json:"download_expiry"`
+	ExternalURL                           string                 `json:"external_url"`
+	ButtonText                            string                 `json:"button_text"`
+	TaxStatus                             string                 `json:"tax_status"`
+	TaxClass                              string                 `json:"tax_class"`
+	ManageStock                           bool                   `json:"manage_stock"`
+	StockQuantity                         *int                   `json:"stock_quantity"`
+	StockStatus                           string                 `json:"stock_status"`
+	InStock                               bool                   `json:"in_stock"`
+	Backorders                            string                 `json:"backorders"`
+	BackordersAllowed                     bool                   `json:"backorders_allowed"`
+	Backordered                           bool                   `json:"backordered"`
+	SoldIndividually                      bool                   `json:"sold_individually"`
+	Weight                                string                 `json:"weight"`
+	Dimensions                            Dimensions             `json:"dimensions"`
+	ShippingRequired                      bool                   `json:"shipping_required"`
+	ShippingTaxable                       bool                   `json:"shipping_taxable"`
+	ShippingClass                         string                 `json:"shipping_class"`
+	ShippingClassId                       int                    `json:"shipping_class_id"`
+	ReviewsAllowed                        bool                   `json:"reviews_allowed"`
+	AverageRating                         string                 `json:"average_rating"`
+	RatingCounts                          interface{}            `json:"rating_counts"`
+	ReviewCount                           int                    `json:"review_count"`
+	RatingCount                           int                    `json:"rating_count"`
+	RelatedIds                            []int                  `json:"related_ids"`
+	UpsellIds                             []int                  `json:"upsell_ids"`
+	CrossSellIds                          []int                  `json:"cross_sell_ids"`
+	ParentId                              int                    `json:"parent_id"`
+	PurchaseNote                          string                 `json:"purchase_note"`
+	LowStockAmount                        int                    `json:"low_stock_amount"`
+	Categories                            []ProductCategory      `json:"categories"`
+	CategoryIds                           []int                  `json:"category_ids"`
+	Tags                                  []ProductTag           `json:"tags"`
+	TagIds                                []int                  `json:"tag_ids"`
+	Image                                 *ProductImage          `json:"image"`
+	Images                                []ProductImage         `json:"images"`
+	Attributes                            []ProductAttribute     `json:"attributes"`
+	DefaultAttributes                     []ProductAttribute     `json:"default_attributes"`
+	Variations                            []int                  `json:"variations"`
+	GroupedProducts                       []int                  `json:"grouped_products"`
+	MenuOrder                             int                    `json:"menu_order"`
+	PostPassword                          string                 `json:"post_password"`
+	ImageID                               string                 `json:"image_id"`
+	GalleryImageIDs                       []int                  `json:"gallery_image_ids"`
+	MetaData                              []MetaDatum            `json:"meta"`
+	MetaDataList                          []MetaDatum            `json:"meta_data,omitempty"`
+	DownloadType                          string                 `json:"download_type"`
+	HasOptions                            bool                   `json:"has_options"`
 	GoogleListingsAndAdsChannelVisibility map[string]interface{} `json:"google_listings_and_ads__channel_visibility"`
-	Links             Links              `json:"_links"`
-	NFE               *ProductNFE        `json:"nfe"`
+	Links                                 Links                  `json:"_links"`
+	Embedded                              Embedded               `json:"_embedded,omitempty"`
+
+	// Extra keeps the fields not modelled above, and Extensions those
+	// registered with RegisterExtension.
+	Extra      Extra      `json:"-"`
+	Extensions Extensions `json:"-"`
+}
+
+// UnmarshalJSON decodes p, keeping the fields it does not model in Extra
+// and Extensions.
+func (p *Product) UnmarshalJSON(data []byte) error {
+	type plain Product
+	return unmarshalExtensible[Product](data, (*plain)(p), &p.Extra, &p.Extensions)
 }
 
+// MarshalJSON encodes p along with its Extra and Extensions fields.
+func (p Product) MarshalJSON() ([]byte, error) {
+	type plain Product
+	return marshalExtensible(plain(p), p.Extra, p.Extensions)
+}
+
+func (p *Product) extensions() *Extensions { return &p.Extensions }
+
 type ProductNFE struct {
 	TipoProduto                  string `json:"tipo_produto"`
 	ClasseImposto                string `json:"classe_imposto"`
@@ -129,42 +166,76 @@ type ProductNFE struct {
 	Origem                       string `json:"origem"`
 }
 
+// StringTime is a date of the API. WooCommerce sends dates without a zone,
+// in the store's timezone or, for *_gmt fields, in UTC; the client places
+// them in the store's timezone after decoding, see WithStoreTimezone. The
+// zero StringTime is an unset date, encoded as null.
 type StringTime time.Time
 
+// floating is the location of dates parsed without a zone, until the client
+// places them in the store's timezone or UTC.
+var floating = time.FixedZone("", 0)
+
+// stringTimeFormats are the layouts StringTime accepts, in the order they
+// are tried. WooCommerce itself sends the first two.
+var stringTimeFormats = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	"02/01/2006",
+	"2006/01/02",
+	"02\\/01\\/2006",
+	"02/01/2006 15:04",
+	"02/01/2006 15:04:05",
+	"02-01-2006 15:04:05",
+	"02-01-2006",
+	"2006-01-02 15:04:05",
+}
+
+// stringTimeLayout is the layout of the dates WooCommerce sends and expects.
+const stringTimeLayout = "2006-01-02T15:04:05"
+
 func (i *StringTime) UnmarshalJSON(t []byte) (err error) {
 	s := strings.Trim(string(t), "\"")
 	if len(s) == 0 || s == "null" {
+		*i = StringTime{}
 		return nil
 	}
-
-	formats := []string{
-		time.RFC3339,
-		"2006-01-02T15:04:05",
-		"2006-01-02",
-		"02/01/2006",
-		"2006/01/02",
-		"02\\/01\\/2006",
-		"02/01/2006 15:04",
-		"02/01/2006 15:04:05",
-		"02-01-2006 15:04:05",
-		"02-01-2006",
-		"2006-01-02 15:04:05",
+	dateTime, _, err := parseStringTime(s)
+	if err == nil {
+		*i = StringTime(dateTime)
 	}
+	return err
+}
 
-	for _, format := range formats {
+// parseStringTime parses s with the first of stringTimeFormats that fits,
+// and returns that format. Dates without a zone are floating.
+func parseStringTime(s string) (time.Time, string, error) {
+	var err error
+	for _, format := range stringTimeFormats {
 		var dateTime time.Time
-		dateTime, err = time.Parse(format, s)
+		dateTime, err = time.ParseInLocation(format, s, floating)
 		if err == nil {
-			*i = StringTime(dateTime)
-			return nil
+			return dateTime, format, nil
 		}
 	}
-	return err
+	return time.Time{}, "", err
 }
 
+// MarshalJSON encodes i in WooCommerce's format, without a zone, as the
+// wall clock of its location, or null if it is unset. The client encodes the
+// dates of request bodies in the store's timezone, and those of *_gmt fields
+// in UTC.
 func (i StringTime) MarshalJSON() ([]byte, error) {
-	t := time.Time(i)
-	return t.MarshalJSON()
+	if i.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + time.Time(i).Format(stringTimeLayout) + `"`), nil
+}
+
+// IsZero reports whether i is unset.
+func (i StringTime) IsZero() bool {
+	return time.Time(i).IsZero()
 }
 
 func (i *StringTime) Time() *time.Time {
@@ -172,6 +243,8 @@ func (i *StringTime) Time() *time.Time {
 	return &t
 }
 
+// StringFloat is a number WooCommerce may send as a string, such as a sales
+// count. Amounts are Money, which is exact.
 type StringFloat float32
 
 func (i *StringFloat) UnmarshalJSON(t []byte) error {
@@ -263,88 +336,27 @@ type ProductListOptions struct {
 }
 
 // ProductBatchOption sets options for batch operations on products.
-type ProductBatchOption struct {
-	Create []Product `json:"create,omitempty"`
-	Update []Product `json:"update,omitempty"`
-	Delete []int64   `json:"delete,omitempty"`
-}
+type ProductBatchOption = BatchOption[Product]
 
 // ProductBatchResource holds the response struct for ProductBatchOption requests.
-type ProductBatchResource struct {
-	Create []*Product `json:"create,omitempty"`
-	Update []*Product `json:"update,omitempty"`
-	Delete []*Product `json:"delete,omitempty"`
-}
+type ProductBatchResource = BatchResource[Product]
 
 type ProductServiceOp struct {
-	client *Client
+	*Resource[Product, ProductListOptions]
 }
 
 const productsBasePath = "products"
 
-// List products.
-func (o *ProductServiceOp) List(options interface{}) ([]Product, error) {
-	products, _, err := o.ListWithPagination(options)
-	return products, err
-}
-
-// ListWithPagination lists products and returns pagination to retrieve next/previous results.
-func (o *ProductServiceOp) ListWithPagination(options interface{}) ([]Product, *Pagination, error) {
-	resource := make([]Product, 0)
-	headers, err := o.client.createAndDoGetHeaders("GET", productsBasePath, nil, options, &resource)
-	if err != nil {
-		return nil, nil, err
-	}
-	// Extract pagination info from header
-	pagination, err := extractPagination(headers)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return resource, pagination, err
-}
-
-func (o *ProductServiceOp) Create(product Product) (*Product, error) {
-	resource := new(Product)
-	err := o.client.Post(productsBasePath, product, &resource)
-	return resource, err
-}
-
-// Get individual product.
-func (o *ProductServiceOp) Get(productID int64, options interface{}) (*Product, error) {
-	path := fmt.Sprintf("%s/%d", productsBasePath, productID)
-	resource := new(Product)
-	err := o.client.Get(path, resource, options)
-	return resource, err
-}
-
-func (o *ProductServiceOp) Update(product *Product) (*Product, error) {
-	path := fmt.Sprintf("%s/%d", productsBasePath, product.ID)
-	resource := new(Product)
-	err := o.client.Put(path, product, &resource)
-	return resource, err
-}
-
-func (o *ProductServiceOp) Delete(productID int64, options interface{}) (*Product, error) {
-	path := fmt.Sprintf("%s/%d", productsBasePath, productID)
-	resource := new(Product)
-	err := o.client.Delete(path, options, &resource)
-	return resource, err
-}
-
-func (o *ProductServiceOp) Batch(data ProductBatchOption) (*ProductBatchResource, error) {
-	path := fmt.Sprintf("%s/batch", productsBasePath)
-	resource := new(ProductBatchResource)
-	err := o.client.Post(path, data, &resource)
-	return resource, err
-}
-
 // ListVariations lists all variations of a product
 func (o *ProductServiceOp) ListVariations(productID int64, options interface{}) ([]Product, error) {
+	return o.ListVariationsWithContext(context.Background(), productID, options)
+}
+
+// ListVariationsWithContext lists all variations of a product, bound to ctx.
+func (o *ProductServiceOp) ListVariationsWithContext(ctx context.Context, productID int64, options interface{}) ([]Product, error) {
 	path := fmt.Sprintf("%s/%d/variations", productsBasePath, productID)
-	resource := make([]Product, 0)
-	err := o.client.Get(path, &resource, options)
-	return resource, err
+	variations := NewResource[Product, ProductListOptions](o.client, path, func(v *Product) int64 { return v.ID })
+	return variations.ListWithContext(ctx, options)
 }
 
 var log = &LeveledLogger{Level: LevelDebug}
@@ -356,7 +368,7 @@ var log = &LeveledLogger{Level: LevelDebug}
 // <https://www.example.com/wp-json/wc/v3/products?page=3>; rel="last"`
 func extractPagination(headers http.Header) (*Pagination, error) {
 	pagination := new(Pagination)
-	linkHeader := headers.Get("Link")
+	linkHeader := strings.Join(headers.Values("Link"), ",")
 	var err error
 	if pagination.Total, err = strconv.ParseUint(headers.Get("X-Wp-Total"), 10, 0); err != nil {
 		pagination.Total = 1
@@ -368,21 +380,22 @@ func extractPagination(headers http.Header) (*Pagination, error) {
 		return pagination, nil
 	}
 
-	for _, link := range strings.Split(linkHeader, ",") {
-		match := linkRegex.FindStringSubmatch(link)
-		// Make sure the link is not empty or invalid
-		if len(match) != 3 {
-			// println("mm", len(match), " ", link, match)
-			// We expect 3 values:
-			// match[0] = full match
-			// match[1] is the URL and match[2] is either 'previous' or 'next', 'first', 'last'
-			// err := ResponseDecodingError{
-			// 	Message: "could not extract pagination link header from " + linkHeader,
-			// }
-			return nil, nil
+	links := linkRegex.FindAllStringSubmatch(linkHeader, -1)
+	if len(links) == 0 {
+		return nil, ResponseDecodingError{
+			Message: "could not extract pagination link header from " + linkHeader,
+		}
+	}
+	for _, link := range links {
+		// link[1] is the URL and link[2] the parameters, e.g. `; rel="next"`
+		relMatch := linkRelRegex.FindStringSubmatch(link[2])
+		if relMatch == nil {
+			return nil, ResponseDecodingError{
+				Message: "pagination link without rel in " + linkHeader,
+			}
 		}
 
-		rel, err := url.Parse(match[1])
+		rel, err := url.Parse(link[1])
 		if err != nil {
 			err = ResponseDecodingError{
 				Message: "pagination does not contain a valid URL",
@@ -395,28 +408,50 @@ func extractPagination(headers http.Header) (*Pagination, error) {
 			return nil, err
 		}
 
-		paginationListOptions := ListOptions{}
-
-		page := params.Get("page")
-		if page != "" {
-			paginationListOptions.Page, err = strconv.Atoi(params.Get("page"))
-			if err != nil {
-				return nil, err
-			}
+		paginationListOptions, err := listOptionsFromQuery(params)
+		if err != nil {
+			return nil, err
 		}
 
-		switch match[2] {
+		switch relMatch[1] {
 		case "next":
-			pagination.NextPageOptions = &paginationListOptions
+			pagination.NextPageOptions = paginationListOptions
 		case "prev":
-			pagination.PreviousPageOptions = &paginationListOptions
+			pagination.PreviousPageOptions = paginationListOptions
 		case "first":
-			pagination.FirstPageOptions = &paginationListOptions
+			pagination.FirstPageOptions = paginationListOptions
 		case "last":
-			pagination.LastPageOptions = &paginationListOptions
+			pagination.LastPageOptions = paginationListOptions
 		}
-
 	}
 
 	return pagination, nil
 }
+
+// listOptionsFromQuery reads the ListOptions fields of a pagination link.
+// Resource specific filters are not kept, use a Pager to follow pages with
+// all the original options.
+func listOptionsFromQuery(params url.Values) (*ListOptions, error) {
+	options := &ListOptions{
+		Context: params.Get("context"),
+		Search:  params.Get("search"),
+		After:   params.Get("after"),
+		Before:  params.Get("before"),
+		Order:   params.Get("order"),
+		Orderby: params.Get("orderby"),
+	}
+	if fields := params.Get("_fields"); fields != "" {
+		options.Fields = strings.Split(fields, ",")
+	}
+	options.Embed = params.Has("_embed")
+	for key, field := range map[string]*int{"page": &options.Page, "per_page": &options.PerPage, "offset": &options.Offset} {
+		if v := params.Get(key); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, err
+			}
+			*field = n
+		}
+	}
+	return options, nil
+}
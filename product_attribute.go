@@ -1,7 +1,7 @@
 package woocommerce
 
 import (
-	"fmt"
+	"context"
 )
 
 const (
@@ -13,8 +13,20 @@ type ProductAttributeService interface {
 	Get(attributeID int64, options interface{}) (*ProductAttributeData, error)
 	List(options interface{}) ([]ProductAttributeData, error)
 	Update(attribute *ProductAttributeData) (*ProductAttributeData, error)
+	Patch(attribute *ProductAttributeData, fields ...string) (*ProductAttributeData, error)
 	Delete(attributeID int64, options interface{}) (*ProductAttributeData, error)
 	Batch(data ProductAttributeBatchOption) (*ProductAttributeBatchResource, error)
+	CreateWithContext(ctx context.Context, attribute ProductAttributeData) (*ProductAttributeData, error)
+	GetWithContext(ctx context.Context, attributeID int64, options interface{}) (*ProductAttributeData, error)
+	ListWithContext(ctx context.Context, options interface{}) ([]ProductAttributeData, error)
+	UpdateWithContext(ctx context.Context, attribute *ProductAttributeData) (*ProductAttributeData, error)
+	PatchWithContext(ctx context.Context, attribute *ProductAttributeData, fields ...string) (*ProductAttributeData, error)
+	DeleteWithContext(ctx context.Context, attributeID int64, options interface{}) (*ProductAttributeData, error)
+	BatchWithContext(ctx context.Context, data ProductAttributeBatchOption) (*ProductAttributeBatchResource, error)
+	ListWithPagination(options interface{}) ([]ProductAttributeData, *Pagination, error)
+	ListWithPaginationWithContext(ctx context.Context, options interface{}) ([]ProductAttributeData, *Pagination, error)
+	Pager(ctx context.Context, options ProductAttributeListOption) *Pager[ProductAttributeData]
+	Base() *Resource[ProductAttributeData, ProductAttributeListOption]
 }
 
 type ProductAttributeData struct {
@@ -25,67 +37,32 @@ type ProductAttributeData struct {
 	Order       int    `json:"order,omitempty"`
 	HasArchives bool   `json:"has_archives,omitempty"`
 	Visible     bool   `json:"visible,omitempty"`
-}
 
-type ProductAttributeListOption struct {
-	ListOptions
-	Hidden bool `url:"hidden,omitempty"`
+	// Extra keeps the fields not modelled above, such as those of plugins.
+	Extra Extra `json:"-"`
 }
 
-type ProductAttributeBatchOption struct {
-	Create []ProductAttributeData `json:"create,omitempty"`
-	Update []ProductAttributeData `json:"update,omitempty"`
-	Delete []int64                `json:"delete,omitempty"`
-}
-
-type ProductAttributeBatchResource struct {
-	Create []*ProductAttributeData `json:"create,omitempty"`
-	Update []*ProductAttributeData `json:"update,omitempty"`
-	Delete []*ProductAttributeData `json:"delete,omitempty"`
-}
-
-type ProductAttributeServiceOp struct {
-	client *Client
+// UnmarshalJSON decodes a, keeping the fields it does not model in Extra.
+func (a *ProductAttributeData) UnmarshalJSON(data []byte) error {
+	type plain ProductAttributeData
+	return unmarshalExtra(data, (*plain)(a), &a.Extra)
 }
 
-func (a *ProductAttributeServiceOp) List(options interface{}) ([]ProductAttributeData, error) {
-	path := fmt.Sprintf("%s", productAttributesBasePath)
-	resource := make([]ProductAttributeData, 0)
-	err := a.client.Get(path, &resource, options)
-	return resource, err
+// MarshalJSON encodes a along with its Extra fields.
+func (a ProductAttributeData) MarshalJSON() ([]byte, error) {
+	type plain ProductAttributeData
+	return marshalExtra(plain(a), a.Extra)
 }
 
-func (a *ProductAttributeServiceOp) Create(attribute ProductAttributeData) (*ProductAttributeData, error) {
-	path := fmt.Sprintf("%s", productAttributesBasePath)
-	resource := new(ProductAttributeData)
-	err := a.client.Post(path, attribute, &resource)
-	return resource, err
-}
-
-func (a *ProductAttributeServiceOp) Get(attributeID int64, options interface{}) (*ProductAttributeData, error) {
-	path := fmt.Sprintf("%s/%d", productAttributesBasePath, attributeID)
-	resource := new(ProductAttributeData)
-	err := a.client.Get(path, resource, options)
-	return resource, err
+type ProductAttributeListOption struct {
+	ListOptions
+	Hidden bool `url:"hidden,omitempty"`
 }
 
-func (a *ProductAttributeServiceOp) Update(attribute *ProductAttributeData) (*ProductAttributeData, error) {
-	path := fmt.Sprintf("%s/%d", productAttributesBasePath, attribute.ID)
-	resource := new(ProductAttributeData)
-	err := a.client.Put(path, attribute, &resource)
-	return resource, err
-}
+type ProductAttributeBatchOption = BatchOption[ProductAttributeData]
 
-func (a *ProductAttributeServiceOp) Delete(attributeID int64, options interface{}) (*ProductAttributeData, error) {
-	path := fmt.Sprintf("%s/%d", productAttributesBasePath, attributeID)
-	resource := new(ProductAttributeData)
-	err := a.client.Delete(path, options, &resource)
-	return resource, err
-}
+type ProductAttributeBatchResource = BatchResource[ProductAttributeData]
 
-func (a *ProductAttributeServiceOp) Batch(data ProductAttributeBatchOption) (*ProductAttributeBatchResource, error) {
-	path := fmt.Sprintf("%s/batch", productAttributesBasePath)
-	resource := new(ProductAttributeBatchResource)
-	err := a.client.Post(path, data, &resource)
-	return resource, err
+type ProductAttributeServiceOp struct {
+	*Resource[ProductAttributeData, ProductAttributeListOption]
 }
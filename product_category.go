@@ -1,7 +1,7 @@
 package woocommerce
 
 import (
-	"fmt"
+	"context"
 )
 
 const (
@@ -13,8 +13,20 @@ type ProductCategoryService interface {
 	Get(categoryID int64, options interface{}) (*ProductCategory, error)
 	List(options interface{}) ([]ProductCategory, error)
 	Update(category *ProductCategory) (*ProductCategory, error)
+	Patch(category *ProductCategory, fields ...string) (*ProductCategory, error)
 	Delete(categoryID int64, options interface{}) (*ProductCategory, error)
 	Batch(data ProductCategoryBatchOption) (*ProductCategoryBatchResource, error)
+	CreateWithContext(ctx context.Context, category ProductCategory) (*ProductCategory, error)
+	GetWithContext(ctx context.Context, categoryID int64, options interface{}) (*ProductCategory, error)
+	ListWithContext(ctx context.Context, options interface{}) ([]ProductCategory, error)
+	UpdateWithContext(ctx context.Context, category *ProductCategory) (*ProductCategory, error)
+	PatchWithContext(ctx context.Context, category *ProductCategory, fields ...string) (*ProductCategory, error)
+	DeleteWithContext(ctx context.Context, categoryID int64, options interface{}) (*ProductCategory, error)
+	BatchWithContext(ctx context.Context, data ProductCategoryBatchOption) (*ProductCategoryBatchResource, error)
+	ListWithPagination(options interface{}) ([]ProductCategory, *Pagination, error)
+	ListWithPaginationWithContext(ctx context.Context, options interface{}) ([]ProductCategory, *Pagination, error)
+	Pager(ctx context.Context, options ProductCategoryListOption) *Pager[ProductCategory]
+	Base() *Resource[ProductCategory, ProductCategoryListOption]
 }
 
 type ProductCategory struct {
@@ -28,6 +40,21 @@ type ProductCategory struct {
 	MenuOrder   int          `json:"menu_order,omitempty"`
 	Count       int64        `json:"count,omitempty"`
 	Links       Links        `json:"_links,omitempty"`
+
+	// Extra keeps the fields not modelled above, such as those of plugins.
+	Extra Extra `json:"-"`
+}
+
+// UnmarshalJSON decodes c, keeping the fields it does not model in Extra.
+func (c *ProductCategory) UnmarshalJSON(data []byte) error {
+	type plain ProductCategory
+	return unmarshalExtra(data, (*plain)(c), &c.Extra)
+}
+
+// MarshalJSON encodes c along with its Extra fields.
+func (c ProductCategory) MarshalJSON() ([]byte, error) {
+	type plain ProductCategory
+	return marshalExtra(plain(c), c.Extra)
 }
 
 type ProductCategoryListOption struct {
@@ -44,60 +71,10 @@ type ProductCategoryListOption struct {
 	Slug      string  `url:"slug,omitempty"`
 }
 
-type ProductCategoryBatchOption struct {
-	Create []ProductCategory `json:"create,omitempty"`
-	Update []ProductCategory `json:"update,omitempty"`
-	Delete []int64           `json:"delete,omitempty"`
-}
+type ProductCategoryBatchOption = BatchOption[ProductCategory]
 
-type ProductCategoryBatchResource struct {
-	Create []*ProductCategory `json:"create,omitempty"`
-	Update []*ProductCategory `json:"update,omitempty"`
-	Delete []*ProductCategory `json:"delete,omitempty"`
-}
+type ProductCategoryBatchResource = BatchResource[ProductCategory]
 
 type ProductCategoryServiceOp struct {
-	client *Client
-}
-
-func (c *ProductCategoryServiceOp) List(options interface{}) ([]ProductCategory, error) {
-	path := fmt.Sprintf("%s", productCategoriesBasePath)
-	resource := make([]ProductCategory, 0)
-	err := c.client.Get(path, &resource, options)
-	return resource, err
-}
-
-func (c *ProductCategoryServiceOp) Create(category ProductCategory) (*ProductCategory, error) {
-	path := fmt.Sprintf("%s", productCategoriesBasePath)
-	resource := new(ProductCategory)
-	err := c.client.Post(path, category, &resource)
-	return resource, err
-}
-
-func (c *ProductCategoryServiceOp) Get(categoryID int64, options interface{}) (*ProductCategory, error) {
-	path := fmt.Sprintf("%s/%d", productCategoriesBasePath, categoryID)
-	resource := new(ProductCategory)
-	err := c.client.Get(path, resource, options)
-	return resource, err
-}
-
-func (c *ProductCategoryServiceOp) Update(category *ProductCategory) (*ProductCategory, error) {
-	path := fmt.Sprintf("%s/%d", productCategoriesBasePath, category.ID)
-	resource := new(ProductCategory)
-	err := c.client.Put(path, category, &resource)
-	return resource, err
-}
-
-func (c *ProductCategoryServiceOp) Delete(categoryID int64, options interface{}) (*ProductCategory, error) {
-	path := fmt.Sprintf("%s/%d", productCategoriesBasePath, categoryID)
-	resource := new(ProductCategory)
-	err := c.client.Delete(path, options, &resource)
-	return resource, err
-}
-
-func (c *ProductCategoryServiceOp) Batch(data ProductCategoryBatchOption) (*ProductCategoryBatchResource, error) {
-	path := fmt.Sprintf("%s/batch", productCategoriesBasePath)
-	resource := new(ProductCategoryBatchResource)
-	err := c.client.Post(path, data, &resource)
-	return resource, err
+	*Resource[ProductCategory, ProductCategoryListOption]
 }
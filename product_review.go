@@ -1,7 +1,7 @@
 package woocommerce
 
 import (
-	"fmt"
+	"context"
 )
 
 const (
@@ -9,19 +9,34 @@ const (
 )
 
 type ProductReview struct {
-	ID                 int64  `json:"id,omitempty"`
-	DateCreated        string `json:"date_created,omitempty"`
-	DateCreatedGmt     string `json:"date_created_gmt,omitempty"`
-	ProductID          int64  `json:"product_id,omitempty"`
-	ProductName        string `json:"product_name,omitempty"`
-	ProductPermalink   string `json:"product_permalink,omitempty"`
-	Status             string `json:"status,omitempty"`
-	Reviewer           string `json:"reviewer,omitempty"`
-	ReviewerEmail      string `json:"reviewer_email,omitempty"`
-	Review             string `json:"review,omitempty"`
-	Rating             int    `json:"rating,omitempty"`
-	Verified           bool   `json:"verified,omitempty"`
+	ID                 int64             `json:"id,omitempty"`
+	DateCreated        string            `json:"date_created,omitempty"`
+	DateCreatedGmt     string            `json:"date_created_gmt,omitempty"`
+	ProductID          int64             `json:"product_id,omitempty"`
+	ProductName        string            `json:"product_name,omitempty"`
+	ProductPermalink   string            `json:"product_permalink,omitempty"`
+	Status             string            `json:"status,omitempty"`
+	Reviewer           string            `json:"reviewer,omitempty"`
+	ReviewerEmail      string            `json:"reviewer_email,omitempty"`
+	Review             string            `json:"review,omitempty"`
+	Rating             int               `json:"rating,omitempty"`
+	Verified           bool              `json:"verified,omitempty"`
 	ReviewerAvatarURLs map[string]string `json:"reviewer_avatar_urls,omitempty"`
+
+	// Extra keeps the fields not modelled above, such as those of plugins.
+	Extra Extra `json:"-"`
+}
+
+// UnmarshalJSON decodes r, keeping the fields it does not model in Extra.
+func (r *ProductReview) UnmarshalJSON(data []byte) error {
+	type plain ProductReview
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+// MarshalJSON encodes r along with its Extra fields.
+func (r ProductReview) MarshalJSON() ([]byte, error) {
+	type plain ProductReview
+	return marshalExtra(plain(r), r.Extra)
 }
 
 type ProductReviewService interface {
@@ -29,8 +44,20 @@ type ProductReviewService interface {
 	Get(reviewID int64, options interface{}) (*ProductReview, error)
 	List(options interface{}) ([]ProductReview, error)
 	Update(review *ProductReview) (*ProductReview, error)
+	Patch(review *ProductReview, fields ...string) (*ProductReview, error)
 	Delete(reviewID int64, options interface{}) (*ProductReview, error)
 	Batch(data ProductReviewBatchOption) (*ProductReviewBatchResource, error)
+	CreateWithContext(ctx context.Context, review ProductReview) (*ProductReview, error)
+	GetWithContext(ctx context.Context, reviewID int64, options interface{}) (*ProductReview, error)
+	ListWithContext(ctx context.Context, options interface{}) ([]ProductReview, error)
+	UpdateWithContext(ctx context.Context, review *ProductReview) (*ProductReview, error)
+	PatchWithContext(ctx context.Context, review *ProductReview, fields ...string) (*ProductReview, error)
+	DeleteWithContext(ctx context.Context, reviewID int64, options interface{}) (*ProductReview, error)
+	BatchWithContext(ctx context.Context, data ProductReviewBatchOption) (*ProductReviewBatchResource, error)
+	ListWithPagination(options interface{}) ([]ProductReview, *Pagination, error)
+	ListWithPaginationWithContext(ctx context.Context, options interface{}) ([]ProductReview, *Pagination, error)
+	Pager(ctx context.Context, options ProductReviewListOption) *Pager[ProductReview]
+	Base() *Resource[ProductReview, ProductReviewListOption]
 }
 
 type ProductReviewListOption struct {
@@ -43,60 +70,10 @@ type ProductReviewListOption struct {
 	Orderby string  `url:"orderby,omitempty"`
 }
 
-type ProductReviewBatchOption struct {
-	Create []ProductReview `json:"create,omitempty"`
-	Update []ProductReview `json:"update,omitempty"`
-	Delete []int64         `json:"delete,omitempty"`
-}
+type ProductReviewBatchOption = BatchOption[ProductReview]
 
-type ProductReviewBatchResource struct {
-	Create []*ProductReview `json:"create,omitempty"`
-	Update []*ProductReview `json:"update,omitempty"`
-	Delete []*ProductReview `json:"delete,omitempty"`
-}
+type ProductReviewBatchResource = BatchResource[ProductReview]
 
 type ProductReviewServiceOp struct {
-	client *Client
-}
-
-func (r *ProductReviewServiceOp) List(options interface{}) ([]ProductReview, error) {
-	path := fmt.Sprintf("%s", productReviewsBasePath)
-	resource := make([]ProductReview, 0)
-	err := r.client.Get(path, &resource, options)
-	return resource, err
-}
-
-func (r *ProductReviewServiceOp) Create(review ProductReview) (*ProductReview, error) {
-	path := fmt.Sprintf("%s", productReviewsBasePath)
-	resource := new(ProductReview)
-	err := r.client.Post(path, review, &resource)
-	return resource, err
-}
-
-func (r *ProductReviewServiceOp) Get(reviewID int64, options interface{}) (*ProductReview, error) {
-	path := fmt.Sprintf("%s/%d", productReviewsBasePath, reviewID)
-	resource := new(ProductReview)
-	err := r.client.Get(path, resource, options)
-	return resource, err
-}
-
-func (r *ProductReviewServiceOp) Update(review *ProductReview) (*ProductReview, error) {
-	path := fmt.Sprintf("%s/%d", productReviewsBasePath, review.ID)
-	resource := new(ProductReview)
-	err := r.client.Put(path, review, &resource)
-	return resource, err
-}
-
-func (r *ProductReviewServiceOp) Delete(reviewID int64, options interface{}) (*ProductReview, error) {
-	path := fmt.Sprintf("%s/%d", productReviewsBasePath, reviewID)
-	resource := new(ProductReview)
-	err := r.client.Delete(path, options, &resource)
-	return resource, err
-}
-
-func (r *ProductReviewServiceOp) Batch(data ProductReviewBatchOption) (*ProductReviewBatchResource, error) {
-	path := fmt.Sprintf("%s/batch", productReviewsBasePath)
-	resource := new(ProductReviewBatchResource)
-	err := r.client.Post(path, data, &resource)
-	return resource, err
+	*Resource[ProductReview, ProductReviewListOption]
 }
@@ -1,7 +1,7 @@
 package woocommerce
 
 import (
-	"fmt"
+	"context"
 )
 
 const (
@@ -13,8 +13,20 @@ type ProductShippingClassService interface {
 	Get(shippingClassID int64, options interface{}) (*ProductShippingClass, error)
 	List(options interface{}) ([]ProductShippingClass, error)
 	Update(shippingClass *ProductShippingClass) (*ProductShippingClass, error)
+	Patch(shippingClass *ProductShippingClass, fields ...string) (*ProductShippingClass, error)
 	Delete(shippingClassID int64, options interface{}) (*ProductShippingClass, error)
 	Batch(data ProductShippingClassBatchOption) (*ProductShippingClassBatchResource, error)
+	CreateWithContext(ctx context.Context, shippingClass ProductShippingClass) (*ProductShippingClass, error)
+	GetWithContext(ctx context.Context, shippingClassID int64, options interface{}) (*ProductShippingClass, error)
+	ListWithContext(ctx context.Context, options interface{}) ([]ProductShippingClass, error)
+	UpdateWithContext(ctx context.Context, shippingClass *ProductShippingClass) (*ProductShippingClass, error)
+	PatchWithContext(ctx context.Context, shippingClass *ProductShippingClass, fields ...string) (*ProductShippingClass, error)
+	DeleteWithContext(ctx context.Context, shippingClassID int64, options interface{}) (*ProductShippingClass, error)
+	BatchWithContext(ctx context.Context, data ProductShippingClassBatchOption) (*ProductShippingClassBatchResource, error)
+	ListWithPagination(options interface{}) ([]ProductShippingClass, *Pagination, error)
+	ListWithPaginationWithContext(ctx context.Context, options interface{}) ([]ProductShippingClass, *Pagination, error)
+	Pager(ctx context.Context, options ProductShippingClassListOption) *Pager[ProductShippingClass]
+	Base() *Resource[ProductShippingClass, ProductShippingClassListOption]
 }
 
 type ProductShippingClass struct {
@@ -23,6 +35,21 @@ type ProductShippingClass struct {
 	Slug  string `json:"slug,omitempty"`
 	Count int64  `json:"count,omitempty"`
 	Links Links  `json:"_links,omitempty"`
+
+	// Extra keeps the fields not modelled above, such as those of plugins.
+	Extra Extra `json:"-"`
+}
+
+// UnmarshalJSON decodes s, keeping the fields it does not model in Extra.
+func (s *ProductShippingClass) UnmarshalJSON(data []byte) error {
+	type plain ProductShippingClass
+	return unmarshalExtra(data, (*plain)(s), &s.Extra)
+}
+
+// MarshalJSON encodes s along with its Extra fields.
+func (s ProductShippingClass) MarshalJSON() ([]byte, error) {
+	type plain ProductShippingClass
+	return marshalExtra(plain(s), s.Extra)
 }
 
 type ProductShippingClassListOption struct {
@@ -32,60 +59,10 @@ type ProductShippingClassListOption struct {
 	Orderby   string `url:"orderby,omitempty"`
 }
 
-type ProductShippingClassBatchOption struct {
-	Create []ProductShippingClass `json:"create,omitempty"`
-	Update []ProductShippingClass `json:"update,omitempty"`
-	Delete []int64                `json:"delete,omitempty"`
-}
+type ProductShippingClassBatchOption = BatchOption[ProductShippingClass]
 
-type ProductShippingClassBatchResource struct {
-	Create []*ProductShippingClass `json:"create,omitempty"`
-	Update []*ProductShippingClass `json:"update,omitempty"`
-	Delete []*ProductShippingClass `json:"delete,omitempty"`
-}
+type ProductShippingClassBatchResource = BatchResource[ProductShippingClass]
 
 type ProductShippingClassServiceOp struct {
-	client *Client
-}
-
-func (s *ProductShippingClassServiceOp) List(options interface{}) ([]ProductShippingClass, error) {
-	path := fmt.Sprintf("%s", productShippingClassesBasePath)
-	resource := make([]ProductShippingClass, 0)
-	err := s.client.Get(path, &resource, options)
-	return resource, err
-}
-
-func (s *ProductShippingClassServiceOp) Create(shippingClass ProductShippingClass) (*ProductShippingClass, error) {
-	path := fmt.Sprintf("%s", productShippingClassesBasePath)
-	resource := new(ProductShippingClass)
-	err := s.client.Post(path, shippingClass, &resource)
-	return resource, err
-}
-
-func (s *ProductShippingClassServiceOp) Get(shippingClassID int64, options interface{}) (*ProductShippingClass, error) {
-	path := fmt.Sprintf("%s/%d", productShippingClassesBasePath, shippingClassID)
-	resource := new(ProductShippingClass)
-	err := s.client.Get(path, resource, options)
-	return resource, err
-}
-
-func (s *ProductShippingClassServiceOp) Update(shippingClass *ProductShippingClass) (*ProductShippingClass, error) {
-	path := fmt.Sprintf("%s/%d", productShippingClassesBasePath, shippingClass.ID)
-	resource := new(ProductShippingClass)
-	err := s.client.Put(path, shippingClass, &resource)
-	return resource, err
-}
-
-func (s *ProductShippingClassServiceOp) Delete(shippingClassID int64, options interface{}) (*ProductShippingClass, error) {
-	path := fmt.Sprintf("%s/%d", productShippingClassesBasePath, shippingClassID)
-	resource := new(ProductShippingClass)
-	err := s.client.Delete(path, options, &resource)
-	return resource, err
-}
-
-func (s *ProductShippingClassServiceOp) Batch(data ProductShippingClassBatchOption) (*ProductShippingClassBatchResource, error) {
-	path := fmt.Sprintf("%s/batch", productShippingClassesBasePath)
-	resource := new(ProductShippingClassBatchResource)
-	err := s.client.Post(path, data, &resource)
-	return resource, err
+	*Resource[ProductShippingClass, ProductShippingClassListOption]
 }
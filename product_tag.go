@@ -1,7 +1,7 @@
 package woocommerce
 
 import (
-	"fmt"
+	"context"
 )
 
 const (
@@ -13,8 +13,20 @@ type ProductTagService interface {
 	Get(tagID int64, options interface{}) (*ProductTag, error)
 	List(options interface{}) ([]ProductTag, error)
 	Update(tag *ProductTag) (*ProductTag, error)
+	Patch(tag *ProductTag, fields ...string) (*ProductTag, error)
 	Delete(tagID int64, options interface{}) (*ProductTag, error)
 	Batch(data ProductTagBatchOption) (*ProductTagBatchResource, error)
+	CreateWithContext(ctx context.Context, tag ProductTag) (*ProductTag, error)
+	GetWithContext(ctx context.Context, tagID int64, options interface{}) (*ProductTag, error)
+	ListWithContext(ctx context.Context, options interface{}) ([]ProductTag, error)
+	UpdateWithContext(ctx context.Context, tag *ProductTag) (*ProductTag, error)
+	PatchWithContext(ctx context.Context, tag *ProductTag, fields ...string) (*ProductTag, error)
+	DeleteWithContext(ctx context.Context, tagID int64, options interface{}) (*ProductTag, error)
+	BatchWithContext(ctx context.Context, data ProductTagBatchOption) (*ProductTagBatchResource, error)
+	ListWithPagination(options interface{}) ([]ProductTag, *Pagination, error)
+	ListWithPaginationWithContext(ctx context.Context, options interface{}) ([]ProductTag, *Pagination, error)
+	Pager(ctx context.Context, options ProductTagListOption) *Pager[ProductTag]
+	Base() *Resource[ProductTag, ProductTagListOption]
 }
 
 type ProductTag struct {
@@ -24,6 +36,21 @@ type ProductTag struct {
 	Description string `json:"description,omitempty"`
 	Count       int64  `json:"count,omitempty"`
 	Links       Links  `json:"_links,omitempty"`
+
+	// Extra keeps the fields not modelled above, such as those of plugins.
+	Extra Extra `json:"-"`
+}
+
+// UnmarshalJSON decodes t, keeping the fields it does not model in Extra.
+func (t *ProductTag) UnmarshalJSON(data []byte) error {
+	type plain ProductTag
+	return unmarshalExtra(data, (*plain)(t), &t.Extra)
+}
+
+// MarshalJSON encodes t along with its Extra fields.
+func (t ProductTag) MarshalJSON() ([]byte, error) {
+	type plain ProductTag
+	return marshalExtra(plain(t), t.Extra)
 }
 
 type ProductTagListOption struct {
@@ -33,60 +60,10 @@ type ProductTagListOption struct {
 	Orderby   string `url:"orderby,omitempty"`
 }
 
-type ProductTagBatchOption struct {
-	Create []ProductTag `json:"create,omitempty"`
-	Update []ProductTag `json:"update,omitempty"`
-	Delete []int64      `json:"delete,omitempty"`
-}
+type ProductTagBatchOption = BatchOption[ProductTag]
 
-type ProductTagBatchResource struct {
-	Create []*ProductTag `json:"create,omitempty"`
-	Update []*ProductTag `json:"update,omitempty"`
-	Delete []*ProductTag `json:"delete,omitempty"`
-}
+type ProductTagBatchResource = BatchResource[ProductTag]
 
 type ProductTagServiceOp struct {
-	client *Client
-}
-
-func (t *ProductTagServiceOp) List(options interface{}) ([]ProductTag, error) {
-	path := fmt.Sprintf("%s", productTagsBasePath)
-	resource := make([]ProductTag, 0)
-	err := t.client.Get(path, &resource, options)
-	return resource, err
-}
-
-func (t *ProductTagServiceOp) Create(tag ProductTag) (*ProductTag, error) {
-	path := fmt.Sprintf("%s", productTagsBasePath)
-	resource := new(ProductTag)
-	err := t.client.Post(path, tag, &resource)
-	return resource, err
-}
-
-func (t *ProductTagServiceOp) Get(tagID int64, options interface{}) (*ProductTag, error) {
-	path := fmt.Sprintf("%s/%d", productTagsBasePath, tagID)
-	resource := new(ProductTag)
-	err := t.client.Get(path, resource, options)
-	return resource, err
-}
-
-func (t *ProductTagServiceOp) Update(tag *ProductTag) (*ProductTag, error) {
-	path := fmt.Sprintf("%s/%d", productTagsBasePath, tag.ID)
-	resource := new(ProductTag)
-	err := t.client.Put(path, tag, &resource)
-	return resource, err
-}
-
-func (t *ProductTagServiceOp) Delete(tagID int64, options interface{}) (*ProductTag, error) {
-	path := fmt.Sprintf("%s/%d", productTagsBasePath, tagID)
-	resource := new(ProductTag)
-	err := t.client.Delete(path, options, &resource)
-	return resource, err
-}
-
-func (t *ProductTagServiceOp) Batch(data ProductTagBatchOption) (*ProductTagBatchResource, error) {
-	path := fmt.Sprintf("%s/batch", productTagsBasePath)
-	resource := new(ProductTagBatchResource)
-	err := t.client.Post(path, data, &resource)
-	return resource, err
+	*Resource[ProductTag, ProductTagListOption]
 }
@@ -6,7 +6,7 @@ import (
 )
 
 func TestProductServiceOp_List(t *testing.T) {
-	options := ProductListOption{
+	options := ProductListOptions{
 		ListOptions: ListOptions{
 			Context: "view",
 			After:   "2021-01-01T00:00:00",
@@ -16,7 +16,6 @@ func TestProductServiceOp_List(t *testing.T) {
 			Page:    1,
 			PerPage: 10,
 		},
-		Type: "simple",
 	}
 	products, err := client.Product.List(options)
 	if err != nil {
@@ -28,15 +27,17 @@ func TestProductServiceOp_List(t *testing.T) {
 }
 
 func TestProductServiceOp_Create(t *testing.T) {
+	price := MustParseMoney("29.99")
+	stock := 100
 	product := Product{
 		Name:             "Test Product " + time.Now().Format("20060102150405"),
 		Type:             "simple",
-		RegularPrice:     "29.99",
+		RegularPrice:     &price,
 		Description:      "A test product",
 		ShortDescription: "Short test product description",
 		SKU:              "test-sku-" + time.Now().Format("20060102150405"),
 		ManageStock:      true,
-		StockQuantity:    "100",
+		StockQuantity:    &stock,
 		Status:           "publish",
 	}
 	res, err := client.Product.Create(product)
@@ -72,10 +73,11 @@ func TestProductServiceOp_Update(t *testing.T) {
 }
 
 func TestProductServiceOp_Delete(t *testing.T) {
+	price := MustParseMoney("19.99")
 	product := Product{
 		Name:         "Test Product to Delete " + time.Now().Format("20060102150405"),
 		Type:         "simple",
-		RegularPrice: "19.99",
+		RegularPrice: &price,
 		Status:       "publish",
 	}
 	created, err := client.Product.Create(product)
@@ -95,18 +97,19 @@ func TestProductServiceOp_Delete(t *testing.T) {
 
 func TestProductServiceOp_Batch(t *testing.T) {
 	timeNow := time.Now().Format("20060102150405")
+	price1, price2 := MustParseMoney("10.99"), MustParseMoney("20.99")
 	data := ProductBatchOption{
 		Create: []Product{
 			{
 				Name:         "Batch Product 1 " + timeNow,
 				Type:         "simple",
-				RegularPrice: "10.99",
+				RegularPrice: &price1,
 				Status:       "publish",
 			},
 			{
 				Name:         "Batch Product 2 " + timeNow,
 				Type:         "simple",
-				RegularPrice: "20.99",
+				RegularPrice: &price2,
 				Status:       "publish",
 			},
 		},
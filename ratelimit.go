@@ -0,0 +1,207 @@
+package woocommerce
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket shared by every request of the clients it is
+// attached to, see WithRateLimit and WithRateLimiter options. Besides its own
+// rate it honours what the store reports: a 429 or an exhausted rate limit
+// header pauses all callers until the store accepts requests again.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second, 0 means unlimited
+	burst  float64
+	tokens float64
+	last   time.Time
+	// until is the time before which the store told us not to send requests.
+	until time.Time
+	clock Clock
+}
+
+// NewRateLimiter returns a limiter allowing rate requests per second on
+// average, with bursts of up to burst requests. A rate of 0 does not limit
+// requests on its own but still backs off when the store asks to.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	burst = max(burst, 1)
+	return &RateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		clock:  realClock{},
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	_, err := l.wait(ctx)
+	return err
+}
+
+// wait is Wait, also returning how long it blocked.
+func (l *RateLimiter) wait(ctx context.Context) (time.Duration, error) {
+	var waited time.Duration
+	for {
+		if err := ctx.Err(); err != nil {
+			return waited, err
+		}
+		d := l.reserve()
+		if d <= 0 {
+			return waited, nil
+		}
+		if err := l.clock.Sleep(ctx, d); err != nil {
+			return waited, err
+		}
+		waited += d
+	}
+}
+
+// reserve takes a token if one is available, otherwise it returns how long
+// to wait before trying again.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.clock.Now()
+	l.refill(now)
+	if now.Before(l.until) {
+		return l.until.Sub(now)
+	}
+	if l.rate <= 0 {
+		return 0
+	}
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	if !l.last.IsZero() && now.After(l.last) {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+}
+
+// observe adjusts the bucket to a response from the store.
+func (l *RateLimiter) observe(status int, limits rateLimitHeaders) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.clock.Now()
+	l.refill(now)
+
+	resume := limits.resume
+	if status == http.StatusTooManyRequests {
+		l.tokens = 0
+		if resume.IsZero() {
+			// The store gave no hint, wait for one token's worth of time or a second.
+			wait := time.Second
+			if l.rate > 0 {
+				wait = time.Duration(float64(time.Second) / l.rate)
+			}
+			resume = now.Add(wait)
+		}
+	}
+	if resume.After(l.until) {
+		l.until = resume
+	}
+	if limits.remaining >= 0 && l.tokens > float64(limits.remaining) {
+		l.tokens = float64(limits.remaining)
+	}
+}
+
+// rateLimitHeaders is what a response tells about the store's rate limit.
+type rateLimitHeaders struct {
+	info RateLimitInfo
+	// remaining is -1 when the store did not report it.
+	remaining int
+	// resume is when requests are accepted again, zero when unknown or now.
+	resume time.Time
+	found  bool
+}
+
+// parseRateLimitHeaders reads Retry-After and the RateLimit-* headers sent by
+// the Store API and by rate limiting plugins or proxies, with or without the
+// X- prefix. Reset is read as a Unix time when large enough to be one, and as
+// a number of seconds otherwise.
+func parseRateLimitHeaders(h http.Header, now time.Time) rateLimitHeaders {
+	r := rateLimitHeaders{remaining: -1}
+	limit, hasLimit := headerInt(h, "RateLimit-Limit", "X-RateLimit-Limit")
+	remaining, hasRemaining := headerInt(h, "RateLimit-Remaining", "X-RateLimit-Remaining")
+	if hasLimit {
+		r.info.BucketSize = limit
+		r.found = true
+	}
+	if hasRemaining {
+		r.remaining = remaining
+		r.found = true
+		if hasLimit {
+			r.info.RequestCount = max(limit-remaining, 0)
+		}
+	}
+
+	retryAfter := parseRetryAfter(h, now)
+	if retryAfter == 0 {
+		if secs, ok := headerInt(h, "RateLimit-Retry-After", "X-RateLimit-Retry-After"); ok && secs > 0 {
+			retryAfter = time.Duration(secs) * time.Second
+		}
+	}
+	if retryAfter > 0 {
+		r.info.RetryAfterSeconds = retryAfter.Seconds()
+		r.resume = now.Add(retryAfter)
+		r.found = true
+	} else if hasRemaining && remaining <= 0 {
+		if reset, ok := headerInt(h, "RateLimit-Reset", "X-RateLimit-Reset"); ok && reset > 0 {
+			resume := now.Add(time.Duration(reset) * time.Second)
+			if reset > 1e9 {
+				resume = time.Unix(int64(reset), 0)
+			}
+			if resume.After(now) {
+				r.info.RetryAfterSeconds = resume.Sub(now).Seconds()
+				r.resume = resume
+			}
+		}
+	}
+	return r
+}
+
+func headerInt(h http.Header, keys ...string) (int, bool) {
+	for _, k := range keys {
+		if v := h.Get(k); v != "" {
+			n, err := strconv.Atoi(v)
+			return n, err == nil
+		}
+	}
+	return 0, false
+}
+
+// updateRateLimits records the rate limit reported by resp in c.RateLimits
+// and feeds it to the client's limiter, if any.
+func (c *Client) updateRateLimits(resp *http.Response) {
+	now := time.Now()
+	if c.limiter != nil {
+		now = c.limiter.clock.Now()
+	}
+	limits := parseRateLimitHeaders(resp.Header, now)
+	if c.limiter != nil {
+		c.limiter.observe(resp.StatusCode, limits)
+	}
+	if !limits.found && resp.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+	c.rateLimitsMu.Lock()
+	c.RateLimits = limits.info
+	c.rateLimitsMu.Unlock()
+}
+
+// CurrentRateLimits returns the rate limit last reported by the store. Unlike
+// reading the RateLimits field, it is safe to call while requests are in flight.
+func (c *Client) CurrentRateLimits() RateLimitInfo {
+	c.rateLimitsMu.Lock()
+	defer c.rateLimitsMu.Unlock()
+	return c.RateLimits
+}
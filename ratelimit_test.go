@@ -0,0 +1,91 @@
+package woocommerce
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_TokenBucket(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	l := NewRateLimiter(2, 3)
+	l.clock = clock
+
+	for i := 0; i < 5; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Three requests use the burst, the next two wait half a second each.
+	if got := clock.Now().Sub(time.Unix(0, 0)); got != time.Second {
+		t.Errorf("waited %s, want 1s", got)
+	}
+}
+
+func TestRateLimiter_WaitHonoursContext(t *testing.T) {
+	l := NewRateLimiter(0.001, 1)
+	l.Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestClient_RateLimitLearnsFromStore(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	limiter := NewRateLimiter(0, 0)
+	limiter.clock = clock
+
+	calls := 0
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("RateLimit-Limit", "25")
+		if calls == 1 {
+			w.Header().Set("RateLimit-Remaining", "0")
+			w.Header().Set("RateLimit-Reset", "7")
+		} else {
+			w.Header().Set("RateLimit-Remaining", "24")
+		}
+		w.Write([]byte(`{"id":1}`))
+	}), WithRateLimiter(limiter))
+
+	if _, err := c.Order.Get(1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.CurrentRateLimits(); got.BucketSize != 25 || got.RequestCount != 25 || got.RetryAfterSeconds != 7 {
+		t.Errorf("RateLimits = %+v", got)
+	}
+	if _, err := c.Order.Get(1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := clock.Now().Sub(time.Unix(0, 0)); got != 7*time.Second {
+		t.Errorf("second call waited %s, want 7s", got)
+	}
+	if got := c.CurrentRateLimits(); got.RequestCount != 1 || got.RetryAfterSeconds != 0 {
+		t.Errorf("RateLimits = %+v", got)
+	}
+}
+
+func TestClient_RateLimitOn429(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	limiter := NewRateLimiter(10, 10)
+	limiter.clock = clock
+	h, _ := statusHandler(http.StatusTooManyRequests)
+	c := newTestClient(t, h, WithRateLimiter(limiter))
+
+	if _, err := c.Order.Get(1, nil); err == nil {
+		t.Fatal("expected error")
+	}
+	if got := c.CurrentRateLimits().RetryAfterSeconds; got != 10 {
+		t.Errorf("RetryAfterSeconds = %v, want 10", got)
+	}
+	start := clock.Now()
+	limiter.Wait(context.Background())
+	if got := clock.Now().Sub(start); got != 10*time.Second {
+		t.Errorf("waited %s after 429, want 10s", got)
+	}
+}
@@ -0,0 +1,130 @@
+package woocommerce
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// Redaction lists what is masked before requests and responses are logged,
+// see WithRedaction option. The zero value masks nothing.
+type Redaction struct {
+	// Headers are header names, matched case-insensitively.
+	Headers []string
+	// Fields are matched case-insensitively against JSON keys and query
+	// parameters, either whole or as an underscore separated part, so "email"
+	// also masks billing_email. The value of a meta_data entry whose key
+	// matches, such as _billing_cpf, is masked too.
+	Fields []string
+}
+
+// DefaultRedaction masks credentials and the personal data found in orders
+// and customers.
+func DefaultRedaction() Redaction {
+	return Redaction{
+		Headers: []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"},
+		Fields: []string{
+			"password", "secret", "token", "consumer_key", "oauth_signature",
+			"cpf", "cnpj", "rg", "ie", "email", "phone", "cellphone",
+			"address_1", "address_2", "postcode",
+		},
+	}
+}
+
+// defaultRedaction applies to logs written without a client, by CheckResponseError.
+var defaultRedaction = DefaultRedaction()
+
+func (r *Redaction) matchField(key string) bool {
+	key = strings.ToLower(key)
+	for _, f := range r.Fields {
+		f = strings.ToLower(f)
+		if key == f || strings.HasPrefix(key, f+"_") || strings.HasSuffix(key, "_"+f) || strings.Contains(key, "_"+f+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+// header returns a copy of h with the values of the listed headers masked.
+func (r *Redaction) header(h http.Header) http.Header {
+	out := h.Clone()
+	for _, name := range r.Headers {
+		if _, ok := out[http.CanonicalHeaderKey(name)]; ok {
+			out.Set(name, redacted)
+		}
+	}
+	return out
+}
+
+// url returns u as a string with the values of the listed query parameters masked.
+func (r *Redaction) url(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+	q := u.Query()
+	for k := range q {
+		if r.matchField(k) {
+			q[k] = []string{redacted}
+		}
+	}
+	masked := *u
+	masked.RawQuery = q.Encode()
+	return masked.String()
+}
+
+// jsonFieldRegex matches a "key": value pair with a scalar value, tolerating
+// a string value cut off by truncation.
+var jsonFieldRegex = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"(\s*:\s*)("(?:[^"\\]|\\.)*"?|-?[0-9][0-9.eE+-]*|true|false)`)
+
+// body returns b with the values of the listed fields masked. Valid JSON is
+// walked so nested values and meta_data entries are covered; anything else,
+// such as a body truncated for logging, is masked field by field.
+func (r *Redaction) body(b []byte) []byte {
+	if len(r.Fields) == 0 || len(b) == 0 {
+		return b
+	}
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err == nil && !dec.More() {
+		if out, err := json.Marshal(r.value(v)); err == nil {
+			return out
+		}
+	}
+	return jsonFieldRegex.ReplaceAllFunc(b, func(m []byte) []byte {
+		sub := jsonFieldRegex.FindSubmatch(m)
+		if !r.matchField(string(sub[1])) {
+			return m
+		}
+		return []byte(`"` + string(sub[1]) + `"` + string(sub[2]) + `"` + redacted + `"`)
+	})
+}
+
+func (r *Redaction) value(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		// meta_data entries carry the field name in "key"
+		if key, ok := v["key"].(string); ok && r.matchField(strings.TrimLeft(key, "_")) {
+			if _, ok := v["value"]; ok {
+				v["value"] = redacted
+			}
+		}
+		for k, e := range v {
+			if r.matchField(k) {
+				v[k] = redacted
+			} else {
+				v[k] = r.value(e)
+			}
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = r.value(e)
+		}
+	}
+	return v
+}
@@ -0,0 +1,52 @@
+package woocommerce
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRedaction_Body(t *testing.T) {
+	r := DefaultRedaction()
+	body := `{"billing":{"first_name":"Ana","email":"ana@example.com","cpf":"123.456.789-00","phone":"5511"},` +
+		`"meta_data":[{"id":1,"key":"_billing_cnpj","value":"00.000.000/0001-00"}],"total":"10.00"}`
+	got := string(r.body([]byte(body)))
+	for _, secret := range []string{"ana@example.com", "123.456.789-00", "5511", "0001-00"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("%q not redacted: %s", secret, got)
+		}
+	}
+	if !strings.Contains(got, `"Ana"`) || !strings.Contains(got, `"10.00"`) {
+		t.Errorf("unrelated fields redacted: %s", got)
+	}
+
+	// Truncated bodies are not valid JSON but must still be masked.
+	got = string(r.body([]byte(`{"billing":{"email":"ana@exam`)))
+	if strings.Contains(got, "ana@") {
+		t.Errorf("truncated body not redacted: %s", got)
+	}
+}
+
+func TestClient_LogsAreRedacted(t *testing.T) {
+	var out bytes.Buffer
+	logger := &LeveledLogger{Level: LevelDebug, stdoutOverride: &out, stderrOverride: &out}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":"invalid","message":"bad email","data":{"email":"ana@example.com"}}`))
+	}), WithLog(logger))
+
+	_, err := c.Order.Create(Order{Billing: &Billing{Email: "ana@example.com", Phone: "5511"}})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	logs := out.String()
+	for _, secret := range []string{"ana@example.com", "5511", customerSecret, "Basic "} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("log leaks %q:\n%s", secret, logs)
+		}
+	}
+	if !strings.Contains(logs, "bad email") {
+		t.Errorf("error not logged by client logger:\n%s", logs)
+	}
+}
@@ -3,19 +3,34 @@ package woocommerce
 // OrderRefundService allows you to create, view, and delete individual WooCommerce Order refunds.
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#refunds
 type OrderRefundService interface {
-  Create()
-  Get()
-  Delete()
-  List()
+	Create()
+	Get()
+	Delete()
+	List()
 }
 
 // OrderRefund represent a WooCommerce Order Refund
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#order-refund-properties
 type OrderRefund struct {
-  ID int64 `json:"id,omitempty"`
+	ID int64 `json:"id,omitempty"`
 
-  DateCreated    string `json:"date_created,omitempty"`
-  DateCreatedGmt string `json:"date_created_gmt,omitempty"`
+	DateCreated    string `json:"date_created,omitempty"`
+	DateCreatedGmt string `json:"date_created_gmt,omitempty"`
 
-  Amount string `json:"amount,omitempty"`
+	Amount Money `json:"amount,omitzero"`
+
+	// Extra keeps the fields not modelled above, such as the reason and line items.
+	Extra Extra `json:"-"`
+}
+
+// UnmarshalJSON decodes r, keeping the fields it does not model in Extra.
+func (r *OrderRefund) UnmarshalJSON(data []byte) error {
+	type plain OrderRefund
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+// MarshalJSON encodes r along with its Extra fields.
+func (r OrderRefund) MarshalJSON() ([]byte, error) {
+	type plain OrderRefund
+	return marshalExtra(plain(r), r.Extra)
 }
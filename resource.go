@@ -0,0 +1,166 @@
+package woocommerce
+
+import (
+	"context"
+	"fmt"
+)
+
+// Resource implements the List, Get, Create, Update, Delete and Batch
+// endpoints of a WooCommerce collection of T under a base path. ListOpt is
+// the list option struct of the collection, such as OrderListOption.
+//
+// The services are built on a Resource, which can also be used on its own
+// for endpoints added by plugins:
+//
+//	type Booking struct {
+//		ID     int64  `json:"id,omitempty"`
+//		Status string `json:"status,omitempty"`
+//	}
+//
+//	bookings := woocommerce.NewResource[Booking, woocommerce.ListOptions](client, "bookings",
+//		func(b *Booking) int64 { return b.ID })
+//	booking, err := bookings.GetWithContext(ctx, 42, nil)
+//
+// Single object methods return a nil object along with any error, as do
+// the services not built on a Resource. Batch returns the results of the
+// chunks that succeeded along with a *BatchError.
+type Resource[T any, ListOpt any] struct {
+	client *Client
+	path   string
+	id     func(*T) int64
+	// fields are requested as _fields by List and Get, see Project.
+	fields []string
+}
+
+// NewResource returns the Resource at path, relative to the client's API
+// prefix, e.g. "orders" or "subscriptions/12/notes". id returns the ID of an
+// object, used by Update to build its path.
+func NewResource[T any, ListOpt any](c *Client, path string, id func(*T) int64) *Resource[T, ListOpt] {
+	return &Resource[T, ListOpt]{client: c, path: path, id: id}
+}
+
+// Base returns r. Services return the Resource they are built on, e.g. to
+// Project it.
+func (r *Resource[T, ListOpt]) Base() *Resource[T, ListOpt] {
+	return r
+}
+
+// Path returns the base path of the resource.
+func (r *Resource[T, ListOpt]) Path() string {
+	return r.path
+}
+
+func (r *Resource[T, ListOpt]) itemPath(id int64) string {
+	return fmt.Sprintf("%s/%d", r.path, id)
+}
+
+// List lists the first page of objects matching options.
+func (r *Resource[T, ListOpt]) List(options interface{}) ([]T, error) {
+	return r.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext is like List but bound to ctx.
+func (r *Resource[T, ListOpt]) ListWithContext(ctx context.Context, options interface{}) ([]T, error) {
+	items, _, err := r.ListWithPaginationWithContext(ctx, options)
+	return items, err
+}
+
+// ListWithPagination lists objects and returns pagination to retrieve next/previous results.
+func (r *Resource[T, ListOpt]) ListWithPagination(options interface{}) ([]T, *Pagination, error) {
+	return r.ListWithPaginationWithContext(context.Background(), options)
+}
+
+// ListWithPaginationWithContext is like ListWithPagination but bound to ctx.
+func (r *Resource[T, ListOpt]) ListWithPaginationWithContext(ctx context.Context, options interface{}) ([]T, *Pagination, error) {
+	options, err := r.withFields(options)
+	if err != nil {
+		return nil, nil, err
+	}
+	items := make([]T, 0)
+	headers, err := r.client.createAndDoGetHeaders(ctx, "GET", r.path, nil, options, &items)
+	if err != nil {
+		return nil, nil, err
+	}
+	pagination, err := extractPagination(headers)
+	if err != nil {
+		return nil, nil, err
+	}
+	return items, pagination, nil
+}
+
+// Pager returns a Pager over every object matching options.
+func (r *Resource[T, ListOpt]) Pager(ctx context.Context, options ListOpt) *Pager[T] {
+	return NewPager(ctx, r.ListWithPaginationWithContext, options)
+}
+
+// Get gets an individual object. options may be a GetOption.
+func (r *Resource[T, ListOpt]) Get(id int64, options interface{}) (*T, error) {
+	return r.GetWithContext(context.Background(), id, options)
+}
+
+// GetWithContext is like Get but bound to ctx.
+func (r *Resource[T, ListOpt]) GetWithContext(ctx context.Context, id int64, options interface{}) (*T, error) {
+	options, err := r.withFields(options)
+	if err != nil {
+		return nil, err
+	}
+	item := new(T)
+	if err := r.client.GetWithContext(ctx, r.itemPath(id), item, options); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Create creates an object and returns it as stored by WooCommerce.
+func (r *Resource[T, ListOpt]) Create(item T) (*T, error) {
+	return r.CreateWithContext(context.Background(), item)
+}
+
+// CreateWithContext is like Create but bound to ctx.
+func (r *Resource[T, ListOpt]) CreateWithContext(ctx context.Context, item T) (*T, error) {
+	created := new(T)
+	if err := r.client.PostWithContext(ctx, r.path, item, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// Update updates the object with the ID of item.
+func (r *Resource[T, ListOpt]) Update(item *T) (*T, error) {
+	return r.UpdateWithContext(context.Background(), item)
+}
+
+// UpdateWithContext is like Update but bound to ctx.
+func (r *Resource[T, ListOpt]) UpdateWithContext(ctx context.Context, item *T) (*T, error) {
+	updated := new(T)
+	if err := r.client.PutWithContext(ctx, r.itemPath(r.id(item)), item, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Delete deletes an object. Resources that support trashing are only
+// deleted when options sets force to true.
+func (r *Resource[T, ListOpt]) Delete(id int64, options interface{}) (*T, error) {
+	return r.DeleteWithContext(context.Background(), id, options)
+}
+
+// DeleteWithContext is like Delete but bound to ctx.
+func (r *Resource[T, ListOpt]) DeleteWithContext(ctx context.Context, id int64, options interface{}) (*T, error) {
+	deleted := new(T)
+	if err := r.client.DeleteWithContext(ctx, r.itemPath(id), options, deleted); err != nil {
+		return nil, err
+	}
+	return deleted, nil
+}
+
+// Batch creates, updates and deletes objects in as few requests as
+// WooCommerce accepts, see RunBatch.
+func (r *Resource[T, ListOpt]) Batch(option BatchOption[T]) (*BatchResource[T], error) {
+	return r.BatchWithContext(context.Background(), option)
+}
+
+// BatchWithContext is like Batch but bound to ctx.
+func (r *Resource[T, ListOpt]) BatchWithContext(ctx context.Context, option BatchOption[T]) (*BatchResource[T], error) {
+	return postBatch(ctx, r.client, r.path+"/batch", option)
+}
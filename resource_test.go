@@ -0,0 +1,195 @@
+package woocommerce
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+type testBooking struct {
+	ID     int64  `json:"id,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+type testBookingListOption struct {
+	ListOptions
+	Status string `url:"status,omitempty"`
+}
+
+func TestResource_CRUD(t *testing.T) {
+	type call struct{ method, path, body string }
+	var calls []call
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, call{r.Method, r.URL.Path, string(body)})
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/wp-json/wc/v3/bookings":
+			w.Header().Set("X-WP-Total", "2")
+			w.Header().Set("X-WP-TotalPages", "1")
+			fmt.Fprint(w, `[{"id":1,"status":"paid"},{"id":2,"status":"paid"}]`)
+		case r.URL.Path == "/wp-json/wc/v3/bookings/404":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code":"booking_invalid_id","message":"Invalid ID.","data":{"status":404}}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/wp-json/wc/v3/bookings":
+			fmt.Fprint(w, `{"id":3,"status":"unpaid"}`)
+		default:
+			fmt.Fprint(w, `{"id":2,"status":"cancelled"}`)
+		}
+	}))
+	bookings := NewResource[testBooking, testBookingListOption](c, "bookings", func(b *testBooking) int64 { return b.ID })
+	ctx := context.Background()
+
+	list, pagination, err := bookings.ListWithPaginationWithContext(ctx, testBookingListOption{Status: "paid"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || pagination.Total != 2 {
+		t.Errorf("list %+v, pagination %+v", list, pagination)
+	}
+	created, err := bookings.CreateWithContext(ctx, testBooking{Status: "unpaid"})
+	if err != nil || created.ID != 3 {
+		t.Errorf("created %+v, %v", created, err)
+	}
+	updated, err := bookings.UpdateWithContext(ctx, &testBooking{ID: 2, Status: "cancelled"})
+	if err != nil || updated.Status != "cancelled" {
+		t.Errorf("updated %+v, %v", updated, err)
+	}
+	if _, err := bookings.DeleteWithContext(ctx, 2, url.Values{"force": {"true"}}); err != nil {
+		t.Error(err)
+	}
+	got, err := bookings.GetWithContext(ctx, 404, nil)
+	if got != nil || !errors.Is(err, ErrNotFound) {
+		t.Errorf("got %+v, %v; want nil, ErrNotFound", got, err)
+	}
+
+	want := []call{
+		{http.MethodGet, "/wp-json/wc/v3/bookings", ""},
+		{http.MethodPost, "/wp-json/wc/v3/bookings", `{"status":"unpaid"}`},
+		{http.MethodPut, "/wp-json/wc/v3/bookings/2", `{"id":2,"status":"cancelled"}`},
+		{http.MethodDelete, "/wp-json/wc/v3/bookings/2", ""},
+		{http.MethodGet, "/wp-json/wc/v3/bookings/404", ""},
+	}
+	if len(calls) != len(want) {
+		t.Fatalf("calls = %+v", calls)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("call %d = %+v, want %+v", i, calls[i], want[i])
+		}
+	}
+}
+
+func TestDeleteOption_Force(t *testing.T) {
+	var queries []string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		fmt.Fprint(w, `{"id":7}`)
+	}))
+	ctx := context.Background()
+
+	if _, err := c.Product.DeleteWithContext(ctx, 7, DeleteOption{Force: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Order.DeleteWithContext(ctx, 7, DeleteOption{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(queries) != 2 || queries[0] != "force=true" || queries[1] != "" {
+		t.Errorf("delete queries = %q, want force=true and none", queries)
+	}
+}
+
+func TestResource_Pager(t *testing.T) {
+	var queries []url.Values
+	c := newTestClient(t, pagedOrders(&queries))
+	orders := NewResource[Order, OrderListOption](c, "orders", func(o *Order) int64 { return o.ID })
+
+	opts := OrderListOption{Status: []string{"processing"}}
+	opts.PerPage = 2
+	var ids []int64
+	for order, err := range orders.Pager(context.Background(), opts).All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, order.ID)
+	}
+	if fmt.Sprint(ids) != "[1 2 3 4 5]" {
+		t.Errorf("ids = %v", ids)
+	}
+	for _, q := range queries {
+		if q.Get("status[]") != "processing" && q.Get("status") != "processing" {
+			t.Errorf("filter lost in %v", q)
+		}
+	}
+}
+
+func TestResource_Batch(t *testing.T) {
+	var paths []string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		var option BatchOption[ProductTag]
+		json.NewDecoder(r.Body).Decode(&option)
+		json.NewEncoder(w).Encode(BatchResource[ProductTag]{Create: ptrs(option.Create)})
+	}))
+
+	option := ProductTagBatchOption{}
+	for i := range 150 {
+		option.Create = append(option.Create, ProductTag{Name: fmt.Sprint(i)})
+	}
+	res, err := c.ProductTag.Batch(option)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Create) != 150 || res.Create[149].Name != "149" {
+		t.Errorf("unexpected result with %d creates", len(res.Create))
+	}
+	if len(paths) != 2 || paths[0] != "/wp-json/wc/v3/products/tags/batch" {
+		t.Errorf("paths = %v", paths)
+	}
+}
+
+func ptrs[T any](items []T) []*T {
+	out := make([]*T, len(items))
+	for i := range items {
+		out[i] = &items[i]
+	}
+	return out
+}
+
+func TestResource_NestedServices(t *testing.T) {
+	var paths []string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		fmt.Fprint(w, `{"id":9}`)
+	}))
+
+	if _, _, err := c.Subscription.GetOrders(12, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.SubscriptionNote.Create(12, "renewed"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.OrderNote.List(5, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Product.ListVariations(3, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := fmt.Sprint([]string{
+		"GET /wp-json/wc/v3/subscriptions/12/orders",
+		"POST /wp-json/wc/v3/subscriptions/12/notes",
+		"GET /wp-json/wc/v3/orders/5/notes",
+		"GET /wp-json/wc/v3/products/3/variations",
+	})
+	if fmt.Sprint(paths) != want {
+		t.Errorf("paths = %v", paths)
+	}
+}
@@ -0,0 +1,216 @@
+package woocommerce
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Clock is the time source used by the retry loop. It can be replaced in
+// tests so backoff can be exercised without sleeping.
+type Clock interface {
+	Now() time.Time
+	// Sleep waits for d or until ctx is done, returning ctx.Err() in the latter case.
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) Sleep(ctx context.Context, d time.Duration) error { return sleepContext(ctx, d) }
+
+// RetryPolicy controls how failed requests are retried, see WithRetryPolicy option.
+// Zero fields, other than Jitter and MaxElapsed, fall back to the values of
+// DefaultRetryPolicy.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// A value of 1 disables retries.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry. Each following wait
+	// is multiplied by Multiplier, up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter randomly shortens each wait by up to this fraction (0 to 1) so
+	// that many clients do not retry in lockstep.
+	Jitter float64
+	// MaxElapsed bounds the total time spent on a call, including waits.
+	// Zero means no limit besides the request context.
+	MaxElapsed time.Duration
+
+	// RetryableStatuses are the response statuses retried for idempotent methods.
+	RetryableStatuses []int
+	// RetryableError reports whether a transport error is worth retrying for
+	// idempotent methods. Context errors are never retried.
+	RetryableError func(err error) bool
+
+	// IdempotentMethods lists the methods that are safe to send twice.
+	IdempotentMethods []string
+	// NonIdempotentStatuses are the statuses for which other methods, such as
+	// a POST creating an order, are retried. They must only contain statuses
+	// that guarantee the store did not act on the request.
+	NonIdempotentStatuses []int
+
+	// Clock defaults to the wall clock.
+	Clock Clock
+
+	// random returns a value in [0, 1) used for jitter, overridden in tests.
+	random func() float64
+}
+
+// DefaultRetryPolicy returns the policy used by WithRetry: exponential
+// backoff from 500ms to 30s with 20% jitter, retrying 429, 502, 503 and 504
+// and network errors for idempotent methods, and only 429 for POST.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryableError: isTemporaryNetError,
+		IdempotentMethods: []string{
+			http.MethodGet,
+			http.MethodHead,
+			http.MethodOptions,
+			http.MethodPut,
+			http.MethodDelete,
+		},
+		NonIdempotentStatuses: []int{http.StatusTooManyRequests},
+	}
+}
+
+// withDefaults fills the zero fields of p from DefaultRetryPolicy.
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	d := DefaultRetryPolicy()
+	if p.MaxAttempts == 0 {
+		p.MaxAttempts = d.MaxAttempts
+	}
+	if p.InitialBackoff == 0 {
+		p.InitialBackoff = d.InitialBackoff
+	}
+	if p.MaxBackoff == 0 {
+		p.MaxBackoff = d.MaxBackoff
+	}
+	if p.Multiplier == 0 {
+		p.Multiplier = d.Multiplier
+	}
+	if p.RetryableStatuses == nil {
+		p.RetryableStatuses = d.RetryableStatuses
+	}
+	if p.RetryableError == nil {
+		p.RetryableError = d.RetryableError
+	}
+	if p.IdempotentMethods == nil {
+		p.IdempotentMethods = d.IdempotentMethods
+	}
+	if p.NonIdempotentStatuses == nil {
+		p.NonIdempotentStatuses = d.NonIdempotentStatuses
+	}
+	if p.Clock == nil {
+		p.Clock = realClock{}
+	}
+	if p.random == nil {
+		p.random = rand.Float64
+	}
+	return p
+}
+
+// noRetryPolicy is used when no retry option was given.
+var noRetryPolicy = RetryPolicy{MaxAttempts: 1}.withDefaults()
+
+func (p *RetryPolicy) idempotent(method string) bool {
+	return slices.Contains(p.IdempotentMethods, method)
+}
+
+// retryStatus reports whether a response with status may be retried for method.
+func (p *RetryPolicy) retryStatus(method string, status int) bool {
+	if p.idempotent(method) {
+		return slices.Contains(p.RetryableStatuses, status)
+	}
+	return slices.Contains(p.NonIdempotentStatuses, status)
+}
+
+// retryError reports whether a transport error may be retried for method.
+// A failed POST may already have created the resource, so it is never retried.
+func (p *RetryPolicy) retryError(method string, err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	return p.idempotent(method) && p.RetryableError(err)
+}
+
+// backoff returns the wait before retry number n (1 for the first retry).
+func (p *RetryPolicy) backoff(n int) time.Duration {
+	d := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(n-1))
+	if d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		d -= d * math.Min(p.Jitter, 1) * p.random()
+	}
+	return time.Duration(d)
+}
+
+// nextWait returns how long to wait before the next attempt, or false when
+// the call should give up. attempt is the number of attempts made so far and
+// retryAfter is the server supplied delay, if any.
+func (p *RetryPolicy) nextWait(attempt int, start time.Time, retryAfter time.Duration) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, false
+	}
+	wait := p.backoff(attempt)
+	if retryAfter > wait {
+		wait = retryAfter
+	}
+	if p.MaxElapsed > 0 && p.Clock.Now().Add(wait).Sub(start) > p.MaxElapsed {
+		return 0, false
+	}
+	return wait, true
+}
+
+// isTemporaryNetError treats timeouts, refused or reset connections and
+// connections closed mid-response as transient. Errors such as bad URLs,
+// unknown hosts or TLS failures are not.
+func isTemporaryNetError(err error) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(h http.Header, now time.Time) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.ParseFloat(v, 64); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs * float64(time.Second))
+	}
+	if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
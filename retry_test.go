@@ -0,0 +1,213 @@
+package woocommerce
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// fakeClock advances instantly and records every wait.
+type fakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	waits []time.Duration
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.waits = append(f.waits, d)
+	f.now = f.now.Add(d)
+	return ctx.Err()
+}
+
+// roundTripFunc lets tests stub the transport of the client's http.Client.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func testRetryPolicy(clock Clock) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: time.Second,
+		MaxBackoff:     3 * time.Second,
+		Clock:          clock,
+	}
+}
+
+func statusHandler(statuses ...int) (http.Handler, *int) {
+	calls := 0
+	var mu sync.Mutex
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		status := statuses[min(calls, len(statuses)-1)]
+		calls++
+		mu.Unlock()
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "10")
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(`{"id":1}`))
+	}), &calls
+}
+
+func TestRetryPolicy_BackoffAndStatuses(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	h, calls := statusHandler(http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout, http.StatusOK)
+	c := newTestClient(t, h, WithRetryPolicy(testRetryPolicy(clock)))
+
+	if _, err := c.Order.Get(1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if *calls != 4 {
+		t.Errorf("calls = %d, want 4", *calls)
+	}
+	want := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}
+	if len(clock.waits) != len(want) {
+		t.Fatalf("waits = %v, want %v", clock.waits, want)
+	}
+	for i := range want {
+		if clock.waits[i] != want[i] {
+			t.Errorf("wait %d = %s, want %s", i, clock.waits[i], want[i])
+		}
+	}
+}
+
+func TestRetryPolicy_NonIdempotent(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	h, calls := statusHandler(http.StatusServiceUnavailable, http.StatusOK)
+	c := newTestClient(t, h, WithRetryPolicy(testRetryPolicy(clock)))
+
+	if _, err := c.Order.Create(Order{}); err == nil {
+		t.Fatal("expected POST not to be retried on 503")
+	}
+	if *calls != 1 {
+		t.Errorf("calls = %d, want 1", *calls)
+	}
+
+	// 429 means the store rejected the request outright, so POST is retried
+	// and the Retry-After delay wins over the shorter backoff.
+	var bodies []string
+	c.Client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		status := http.StatusOK
+		header := http.Header{}
+		if len(bodies) == 1 {
+			status = http.StatusTooManyRequests
+			header.Set("Retry-After", "10")
+		}
+		return &http.Response{StatusCode: status, Header: header, Body: io.NopCloser(strings.NewReader(`{"id":7}`))}, nil
+	})
+	order, err := c.Order.Create(Order{Status: "pending"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.ID != 7 || len(bodies) != 2 {
+		t.Fatalf("order %d after %d calls", order.ID, len(bodies))
+	}
+	if bodies[0] == "" || bodies[0] != bodies[1] {
+		t.Errorf("request body not resent on retry: %q", bodies)
+	}
+	if clock.waits[len(clock.waits)-1] != 10*time.Second {
+		t.Errorf("Retry-After not honoured, waits %v", clock.waits)
+	}
+}
+
+func TestRetryPolicy_NetworkErrors(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	c := newTestClient(t, http.NotFoundHandler(), WithRetryPolicy(testRetryPolicy(clock)))
+	calls := 0
+	c.Client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return nil, syscall.ECONNRESET
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+	})
+
+	if _, err := c.Product.Get(1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("GET calls = %d, want 2", calls)
+	}
+
+	calls = 0
+	if _, err := c.Product.Create(Product{}); !errors.Is(err, syscall.ECONNRESET) {
+		t.Fatalf("expected POST network error to be returned, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("POST calls = %d, want 1", calls)
+	}
+}
+
+func TestRetryPolicy_MaxElapsed(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	policy := testRetryPolicy(clock)
+	policy.MaxElapsed = 2500 * time.Millisecond
+	h, calls := statusHandler(http.StatusServiceUnavailable)
+	c := newTestClient(t, h, WithRetryPolicy(policy))
+
+	if _, err := c.Order.Get(1, nil); err == nil {
+		t.Fatal("expected error")
+	}
+	// 1s then 2s of backoff would exceed 2.5s, so only one retry happens.
+	if *calls != 2 {
+		t.Errorf("calls = %d, want 2", *calls)
+	}
+}
+
+func TestRetryPolicy_Jitter(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: time.Second, Jitter: 0.5}.withDefaults()
+	p.random = func() float64 { return 0.5 }
+	if got := p.backoff(1); got != 750*time.Millisecond {
+		t.Errorf("backoff = %s, want 750ms", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := http.Header{}
+	h.Set("Retry-After", "1.5")
+	if got := parseRetryAfter(h, now); got != 1500*time.Millisecond {
+		t.Errorf("seconds: got %s", got)
+	}
+	h.Set("Retry-After", now.Add(time.Minute).Format(http.TimeFormat))
+	if got := parseRetryAfter(h, now); got != time.Minute {
+		t.Errorf("date: got %s", got)
+	}
+}
+
+func TestIsTemporaryNetError(t *testing.T) {
+	for _, tt := range []struct {
+		err  error
+		want bool
+	}{
+		{&net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, true},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, true},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "i/o timeout", Name: "shop.example.com", IsTimeout: true}}, true},
+		{io.ErrUnexpectedEOF, true},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "shop.example.com", IsNotFound: true}}, false},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ENETUNREACH)}, false},
+		{errors.New("x509: certificate signed by unknown authority"), false},
+	} {
+		if got := isTemporaryNetError(tt.err); got != tt.want {
+			t.Errorf("isTemporaryNetError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package woocommerce
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"time"
+)
+
+// SlogLogger adapts a slog.Logger to LeveledLoggerInterface, see WithSlog
+// option. Besides the formatted message, logs written by the client carry
+// attributes such as method, endpoint, id, status, attempt and duration.
+type SlogLogger struct {
+	Logger *slog.Logger
+}
+
+// NewSlogLogger returns a SlogLogger writing to h.
+func NewSlogLogger(h slog.Handler) *SlogLogger {
+	return &SlogLogger{Logger: slog.New(h)}
+}
+
+// Debugf logs a debug message using Printf conventions.
+func (l *SlogLogger) Debugf(format string, v ...interface{}) { l.log(slog.LevelDebug, format, v...) }
+
+// Errorf logs an error message using Printf conventions.
+func (l *SlogLogger) Errorf(format string, v ...interface{}) { l.log(slog.LevelError, format, v...) }
+
+// Infof logs an informational message using Printf conventions.
+func (l *SlogLogger) Infof(format string, v ...interface{}) { l.log(slog.LevelInfo, format, v...) }
+
+// Warnf logs a warning message using Printf conventions.
+func (l *SlogLogger) Warnf(format string, v ...interface{}) { l.log(slog.LevelWarn, format, v...) }
+
+// WithAttrs returns a logger adding attrs to every record. Custom loggers can
+// implement the same method to receive the client's structured attributes.
+func (l *SlogLogger) WithAttrs(attrs ...slog.Attr) LeveledLoggerInterface {
+	args := make([]any, len(attrs))
+	for i, a := range attrs {
+		args[i] = a
+	}
+	return &SlogLogger{Logger: l.Logger.With(args...)}
+}
+
+func (l *SlogLogger) log(level slog.Level, format string, v ...interface{}) {
+	ctx := context.Background()
+	if !l.Logger.Enabled(ctx, level) {
+		return
+	}
+	// Skip runtime.Callers, log and the Debugf-like wrapper so the source
+	// points at the caller.
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:])
+	r := slog.NewRecord(time.Now(), level, fmt.Sprintf(format, v...), pcs[0])
+	_ = l.Logger.Handler().Handle(ctx, r)
+}
+
+// attrLogger is implemented by loggers accepting structured attributes.
+type attrLogger interface {
+	WithAttrs(attrs ...slog.Attr) LeveledLoggerInterface
+}
+
+// logWith returns log with attrs attached when it supports them, and log
+// itself otherwise, so printf-only loggers keep their output unchanged.
+func logWith(log LeveledLoggerInterface, attrs ...slog.Attr) LeveledLoggerInterface {
+	if l, ok := log.(attrLogger); ok && len(attrs) > 0 {
+		return l.WithAttrs(attrs...)
+	}
+	return log
+}
+
+// requestAttrs are the attributes describing a request to endpoint e.
+func requestAttrs(method string, e Endpoint) []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("endpoint", e.String()),
+	}
+	if e.ID != "" {
+		attrs = append(attrs, slog.String("id", e.ID))
+	}
+	return attrs
+}
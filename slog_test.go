@@ -0,0 +1,47 @@
+package woocommerce
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_WithSlog(t *testing.T) {
+	var out bytes.Buffer
+	h, _ := statusHandler(http.StatusNotFound)
+	c := newTestClient(t, h, WithSlog(slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug, AddSource: true})))
+
+	if _, err := c.Order.Get(42, nil); err == nil {
+		t.Fatal("expected error")
+	}
+
+	var found bool
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("invalid record %q: %v", line, err)
+		}
+		if rec["method"] != "GET" || rec["endpoint"] != "orders.get" || rec["id"] != "42" {
+			t.Errorf("record without request attributes: %s", line)
+		}
+		if rec["level"] == "ERROR" && rec["status"] == float64(404) && rec["attempt"] == float64(1) && rec["duration"] != nil {
+			found = true
+		}
+		if src, _ := rec["source"].(map[string]any); strings.HasSuffix(src["file"].(string), "slog.go") {
+			t.Errorf("source points at the adapter: %v", src)
+		}
+	}
+	if !found {
+		t.Errorf("no error record with status, attempt and duration:\n%s", out.String())
+	}
+}
+
+func TestLogWith_LeveledLogger(t *testing.T) {
+	l := &LeveledLogger{Level: LevelDebug}
+	if got := logWith(l, slog.Int("status", 200)); got != l {
+		t.Errorf("printf logger should be returned as is, got %T", got)
+	}
+}
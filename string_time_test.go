@@ -35,18 +35,23 @@ func TestStringTime_UnmarshalJSON(t *testing.T) {
 		{
 			name:    "DD-MM-YYYY",
 			json:    `"19-01-2026"`,
-			wantErr: true, // Currently fails?
+			wantErr: false,
 		},
 		{
 			name:    "YYYY/MM/DD",
 			json:    `"2026/01/19"`,
-			wantErr: true, // Currently fails?
+			wantErr: false,
+		},
+		{
+			name:    "DD/MM/YYYY HH:MM",
+			json:    `"19/01/2026 10:00"`,
+			wantErr: false,
+		},
+		{
+			name:    "invalid",
+			json:    `"next tuesday"`,
+			wantErr: true,
 		},
-        {
-            name: "DD/MM/YYYY HH:MM",
-            json: `"19/01/2026 10:00"`,
-            wantErr: true,
-        },
 	}
 
 	for _, tt := range tests {
@@ -71,3 +76,30 @@ func TestStringTime_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestStringTime_MarshalJSON(t *testing.T) {
+	sp, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skip(err)
+	}
+	tests := []struct {
+		name string
+		in   StringTime
+		want string
+	}{
+		{"unset", StringTime{}, "null"},
+		{"store time", StringTime(time.Date(2026, 1, 19, 10, 0, 0, 0, sp)), `"2026-01-19T10:00:00"`},
+		{"floating", StringTime(time.Date(2026, 1, 19, 10, 0, 0, 0, floating)), `"2026-01-19T10:00:00"`},
+	}
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.in)
+		if err != nil || string(got) != tt.want {
+			t.Errorf("%s: got %s, %v, want %s", tt.name, got, err, tt.want)
+		}
+	}
+
+	var st StringTime
+	if err := json.Unmarshal([]byte("null"), &st); err != nil || !st.IsZero() {
+		t.Errorf("null decoded as %v, %v", time.Time(st), err)
+	}
+}
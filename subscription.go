@@ -1,7 +1,8 @@
 package woocommerce
 
 import (
-	"fmt"
+	"context"
+	"reflect"
 )
 
 const (
@@ -15,15 +16,27 @@ type SubscriptionService interface {
 	Get(subscriptionId int64, options interface{}) (*Subscription, error)
 	List(options interface{}) ([]Subscription, error)
 	Update(subscription *Subscription) (*Subscription, error)
+	Patch(subscription *Subscription, fields ...string) (*Subscription, error)
 	Delete(subscriptionID int64, options interface{}) (*Subscription, error)
 	Batch(option SubscriptionBatchOption) (*SubscriptionBatchResource, error)
 	ListWithPagination(options interface{}) ([]Subscription, *Pagination, error)
 	GetOrders(subscriptionID int64, options interface{}) ([]Order, *Pagination, error)
+	CreateWithContext(ctx context.Context, subscription Subscription) (*Subscription, error)
+	GetWithContext(ctx context.Context, subscriptionId int64, options interface{}) (*Subscription, error)
+	ListWithContext(ctx context.Context, options interface{}) ([]Subscription, error)
+	UpdateWithContext(ctx context.Context, subscription *Subscription) (*Subscription, error)
+	PatchWithContext(ctx context.Context, subscription *Subscription, fields ...string) (*Subscription, error)
+	DeleteWithContext(ctx context.Context, subscriptionID int64, options interface{}) (*Subscription, error)
+	BatchWithContext(ctx context.Context, option SubscriptionBatchOption) (*SubscriptionBatchResource, error)
+	ListWithPaginationWithContext(ctx context.Context, options interface{}) ([]Subscription, *Pagination, error)
+	GetOrdersWithContext(ctx context.Context, subscriptionID int64, options interface{}) ([]Order, *Pagination, error)
+	Pager(ctx context.Context, options SubscriptionListOptions) *Pager[Subscription]
+	Base() *Resource[Subscription, SubscriptionListOptions]
 }
 
 // SubscriptionServiceOp handles communication with the subscription related methods of WooCommerce'API
 type SubscriptionServiceOp struct {
-	client *Client
+	*Resource[Subscription, SubscriptionListOptions]
 }
 
 // SubscriptionListOption list all thee subscription list option request params
@@ -59,46 +72,38 @@ type SubscriptionListOptions struct {
 
 // SubscriptionBatchOption setting  operate for subscription in batch way
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#batch-update-subscriptions
-type SubscriptionBatchOption struct {
-	Create []Subscription `json:"create,omitempty"`
-	Update []Subscription `json:"update,omitempty"`
-	Delete []int64        `json:"delete,omitempty"`
-}
+type SubscriptionBatchOption = BatchOption[Subscription]
 
 // SubscriptionBatchResource conservation the response struct for SubscriptionBatchOption request
-type SubscriptionBatchResource struct {
-	Create []*Subscription `json:"create,omitempty"`
-	Update []*Subscription `json:"update,omitempty"`
-	Delete []*Subscription `json:"delete,omitempty"`
-}
+type SubscriptionBatchResource = BatchResource[Subscription]
 
 // Subscription represents a WooCommerce Subscription
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#subscription-properties
 type Subscription struct {
-	ID                       int64           `json:"id,omitempty"`
-	ParentId                 int64           `json:"parent_id,omitempty"`
-	Status                   string          `json:"status,omitempty"`
-	Currency                 string          `json:"currency,omitempty"`
-	Version                  string          `json:"version,omitempty"`
-	PricesIncludeTax         bool            `json:"prices_include_tax,omitempty"`
-	DateCreated              StringTime      `json:"date_created,omitempty"`
-	DateCreatedGmt           StringTime      `json:"date_created_gmt,omitempty"`
-	DateModified             StringTime      `json:"date_modified,omitempty"`
-	DateModifiedGmt          StringTime      `json:"date_modified_gmt,omitempty"`
-	DateCompleted            StringTime      `json:"date_completed,omitempty"`
-	DateCompletedGmt         StringTime      `json:"date_completed_gmt,omitempty"`
-	DatePaid                 StringTime      `json:"date_paid,omitempty"`
-	DatePaidGmt              StringTime      `json:"date_paid_gmt,omitempty"`
-	StartDate                StringTime      `json:"start_date,omitempty"`
-	StartDateGmt             StringTime      `json:"start_date_gmt,omitempty"`
-	TrialEnd                 StringTime      `json:"trial_end_date,omitempty"`
-	TrialEndGmt              StringTime      `json:"trial_end_date_gmt,omitempty"`
-	NextPaymentDate          StringTime      `json:"next_payment_date,omitempty"`
-	NextPaymentDateGmt       StringTime      `json:"next_payment_date_gmt,omitempty"`
-	LastPaymentDate          StringTime      `json:"last_payment_date,omitempty"`
-	LastPaymentDateGmt       StringTime      `json:"last_payment_date_gmt,omitempty"`
-	PaymentRetryDate         StringTime      `json:"payment_retry_date,omitempty"`
-	
+	ID                 int64      `json:"id,omitempty"`
+	ParentId           int64      `json:"parent_id,omitempty"`
+	Status             string     `json:"status,omitempty"`
+	Currency           string     `json:"currency,omitempty"`
+	Version            string     `json:"version,omitempty"`
+	PricesIncludeTax   bool       `json:"prices_include_tax,omitempty"`
+	DateCreated        StringTime `json:"date_created,omitempty"`
+	DateCreatedGmt     StringTime `json:"date_created_gmt,omitempty"`
+	DateModified       StringTime `json:"date_modified,omitempty"`
+	DateModifiedGmt    StringTime `json:"date_modified_gmt,omitempty"`
+	DateCompleted      StringTime `json:"date_completed,omitempty"`
+	DateCompletedGmt   StringTime `json:"date_completed_gmt,omitempty"`
+	DatePaid           StringTime `json:"date_paid,omitempty"`
+	DatePaidGmt        StringTime `json:"date_paid_gmt,omitempty"`
+	StartDate          StringTime `json:"start_date,omitempty"`
+	StartDateGmt       StringTime `json:"start_date_gmt,omitempty"`
+	TrialEnd           StringTime `json:"trial_end_date,omitempty"`
+	TrialEndGmt        StringTime `json:"trial_end_date_gmt,omitempty"`
+	NextPaymentDate    StringTime `json:"next_payment_date,omitempty"`
+	NextPaymentDateGmt StringTime `json:"next_payment_date_gmt,omitempty"`
+	LastPaymentDate    StringTime `json:"last_payment_date,omitempty"`
+	LastPaymentDateGmt StringTime `json:"last_payment_date_gmt,omitempty"`
+	PaymentRetryDate   StringTime `json:"payment_retry_date,omitempty"`
+
 	// New Fields
 	TrialPeriod              string          `json:"trial_period,omitempty"`
 	SuspensionCount          int             `json:"suspension_count,omitempty"`
@@ -108,13 +113,13 @@ type Subscription struct {
 	CancelledDateGmt         StringTime      `json:"cancelled_date_gmt,omitempty"`
 	EndDate                  StringTime      `json:"end_date,omitempty"`
 	EndDateGmt               StringTime      `json:"end_date_gmt,omitempty"`
-	DiscountsTotal           StringFloat     `json:"discount_total,omitempty"`
-	DiscountsTax             StringFloat     `json:"discount_tax,omitempty"`
-	ShippingTotal            StringFloat     `json:"shipping_total,omitempty"`
-	ShippingTax              StringFloat     `json:"shipping_tax,omitempty"`
-	CartTax                  StringFloat     `json:"cart_tax,omitempty"`
-	Total                    StringFloat     `json:"total,omitempty"`
-	TotalTax                 StringFloat     `json:"total_tax,omitempty"`
+	DiscountsTotal           Money           `json:"discount_total,omitzero"`
+	DiscountsTax             Money           `json:"discount_tax,omitzero"`
+	ShippingTotal            Money           `json:"shipping_total,omitzero"`
+	ShippingTax              Money           `json:"shipping_tax,omitzero"`
+	CartTax                  Money           `json:"cart_tax,omitzero"`
+	Total                    Money           `json:"total,omitzero"`
+	TotalTax                 Money           `json:"total_tax,omitzero"`
 	CustomerId               int64           `json:"customer_id,omitempty"`
 	OrderKey                 string          `json:"order_key,omitempty"`
 	Billing                  *Billing        `json:"billing,omitempty"`
@@ -141,91 +146,51 @@ type Subscription struct {
 	PaymentDetails           PaymentDetails  `json:"payment_details,omitempty"`
 	PaymentUrl               string          `json:"payment_url,omitempty"`
 	TransitionStatus         string          `json:"transition_status,omitempty"`
-	TrackingCode             string          `json:"correios_tracking_code,omitempty"`
 	NeedsPayment             bool            `json:"needs_payment,omitempty"`
 	NeedsProcessing          bool            `json:"needs_processing,omitempty"`
 	IsEditable               bool            `json:"is_editable,omitempty"`
 	Links                    Links           `json:"_links"`
+	Embedded                 Embedded        `json:"_embedded,omitempty"`
 	TransactionId            string          `json:"transaction_id,omitempty"`
 	CartHash                 string          `json:"cart_hash,omitempty"`
 	Refunds                  []Refund        `json:"refunds,omitempty"`
-}
-
-type PaymentDetails struct {
-	PostMeta []MetaData `json:"post_meta,omitempty"`
-	UserMeta []MetaData `json:"user_meta,omitempty"`
-}
 
-func (o *SubscriptionServiceOp) List(options interface{}) ([]Subscription, error) {
-	subscriptions, _, err := o.ListWithPagination(options)
-	return subscriptions, err
+	// Extra keeps the fields not modelled above, and Extensions those
+	// registered with RegisterExtension.
+	Extra      Extra      `json:"-"`
+	Extensions Extensions `json:"-"`
 }
 
-// ListWithPagination lists products and return pagination to retrieve next/previous results.
-func (o *SubscriptionServiceOp) ListWithPagination(options interface{}) ([]Subscription, *Pagination, error) {
-	resource := make([]Subscription, 0)
-	headers, err := o.client.createAndDoGetHeaders("GET", subscriptionsBasePath, nil, options, &resource)
-	if err != nil {
-		return nil, nil, err
+// UnmarshalJSON decodes s, keeping the fields it does not model in Extra
+// and Extensions, and sets the currency of its amounts.
+func (s *Subscription) UnmarshalJSON(data []byte) error {
+	type plain Subscription
+	if err := unmarshalExtensible[Subscription](data, (*plain)(s), &s.Extra, &s.Extensions); err != nil {
+		return err
 	}
-	// Extract pagination info from header
-	pagination, err := extractPagination(headers)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return resource, pagination, err
+	setCurrency(reflect.ValueOf(s), s.Currency)
+	return nil
 }
 
-func (o *SubscriptionServiceOp) Create(subscription Subscription) (*Subscription, error) {
-	resource := new(Subscription)
-
-	err := o.client.Post(subscriptionsBasePath, subscription, &resource)
-	return resource, err
+// MarshalJSON encodes s along with its Extra and Extensions fields.
+func (s Subscription) MarshalJSON() ([]byte, error) {
+	type plain Subscription
+	return marshalExtensible(plain(s), s.Extra, s.Extensions)
 }
 
-// Get individual subscription
-func (o *SubscriptionServiceOp) Get(subscriptionID int64, options interface{}) (*Subscription, error) {
-	path := fmt.Sprintf("%s/%d", subscriptionsBasePath, subscriptionID)
-	resource := new(Subscription)
-	err := o.client.Get(path, resource, options)
-	return resource, err
-}
-
-func (o *SubscriptionServiceOp) Update(subscription *Subscription) (*Subscription, error) {
-	path := fmt.Sprintf("%s/%d", subscriptionsBasePath, subscription.ID)
-	resource := new(Subscription)
-	err := o.client.Put(path, subscription, &resource)
-	return resource, err
-}
+func (s *Subscription) extensions() *Extensions { return &s.Extensions }
 
-func (o *SubscriptionServiceOp) Delete(subscriptionID int64, options interface{}) (*Subscription, error) {
-	path := fmt.Sprintf("%s/%d", subscriptionsBasePath, subscriptionID)
-	resource := new(Subscription)
-	err := o.client.Delete(path, options, &resource)
-	return resource, err
-}
-
-func (o *SubscriptionServiceOp) Batch(data SubscriptionBatchOption) (*SubscriptionBatchResource, error) {
-	path := fmt.Sprintf("%s/batch", subscriptionsBasePath)
-	resource := new(SubscriptionBatchResource)
-	err := o.client.Post(path, data, &resource)
-	return resource, err
+type PaymentDetails struct {
+	PostMeta []MetaData `json:"post_meta,omitempty"`
+	UserMeta []MetaData `json:"user_meta,omitempty"`
 }
 
 // GetOrders lists orders for a subscription and return pagination to retrieve next/previous results.
 func (o *SubscriptionServiceOp) GetOrders(subscriptionID int64, options interface{}) ([]Order, *Pagination, error) {
-	path := fmt.Sprintf("%s/%d/orders", subscriptionsBasePath, subscriptionID)
-	resource := make([]Order, 0)
-	headers, err := o.client.createAndDoGetHeaders("GET", path, nil, options, &resource)
-	if err != nil {
-		return nil, nil, err
-	}
-	// Extract pagination info from header
-	pagination, err := extractPagination(headers)
-	if err != nil {
-		return nil, nil, err
-	}
+	return o.GetOrdersWithContext(context.Background(), subscriptionID, options)
+}
 
-	return resource, pagination, err
+// GetOrdersWithContext is like GetOrders but bound to ctx.
+func (o *SubscriptionServiceOp) GetOrdersWithContext(ctx context.Context, subscriptionID int64, options interface{}) ([]Order, *Pagination, error) {
+	return o.client.SubscriptionOrder.ListWithPaginationWithContext(ctx, subscriptionID, options)
 }
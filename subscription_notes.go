@@ -1,27 +1,39 @@
 package woocommerce
 
 import (
+	"context"
 	"fmt"
 )
 
 const (
-  subscriptionNotesBasePath = "subscriptions/%v/notes"
+	subscriptionNotesBasePath = "subscriptions/%v/notes"
 )
 
 // SubscriptionNoteService is an interface for interfacing with the subscriptionnotes endpoints of woocommerce API
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#subscriptionnotes
 type SubscriptionNoteService interface {
-  Create(subscriptionId int64, subscriptionNote string) (*SubscriptionNote, error)
-  Get(subscriptionId int64, subscriptionNoteId int64, options interface{}) (*SubscriptionNote, error)
-  List(subscriptionId int64, options interface{}) ([]SubscriptionNote, error)
-  Update(subscriptionId int64, subscriptioNnote *SubscriptionNote) (*SubscriptionNote, error)
-  Delete(subscriptionId int64, subscriptioNnoteID int64, options interface{}) (*SubscriptionNote, error)
-  Batch(subscriptionId int64, option SubscriptionNoteBatchOption) (*SubscriptionNoteBatchResource, error)
+	Create(subscriptionId int64, subscriptionNote string) (*SubscriptionNote, error)
+	Get(subscriptionId int64, subscriptionNoteId int64, options interface{}) (*SubscriptionNote, error)
+	List(subscriptionId int64, options interface{}) ([]SubscriptionNote, error)
+	Update(subscriptionId int64, subscriptioNnote *SubscriptionNote) (*SubscriptionNote, error)
+	Patch(subscriptionId int64, subscriptionNote *SubscriptionNote, fields ...string) (*SubscriptionNote, error)
+	Delete(subscriptionId int64, subscriptioNnoteID int64, options interface{}) (*SubscriptionNote, error)
+	Batch(subscriptionId int64, option SubscriptionNoteBatchOption) (*SubscriptionNoteBatchResource, error)
+	CreateWithContext(ctx context.Context, subscriptionId int64, subscriptionNote string) (*SubscriptionNote, error)
+	GetWithContext(ctx context.Context, subscriptionId int64, subscriptionNoteId int64, options interface{}) (*SubscriptionNote, error)
+	ListWithContext(ctx context.Context, subscriptionId int64, options interface{}) ([]SubscriptionNote, error)
+	UpdateWithContext(ctx context.Context, subscriptionId int64, subscriptioNnote *SubscriptionNote) (*SubscriptionNote, error)
+	PatchWithContext(ctx context.Context, subscriptionId int64, subscriptionNote *SubscriptionNote, fields ...string) (*SubscriptionNote, error)
+	DeleteWithContext(ctx context.Context, subscriptionId int64, subscriptioNnoteID int64, options interface{}) (*SubscriptionNote, error)
+	BatchWithContext(ctx context.Context, subscriptionId int64, option SubscriptionNoteBatchOption) (*SubscriptionNoteBatchResource, error)
+	ListWithPagination(subscriptionId int64, options interface{}) ([]SubscriptionNote, *Pagination, error)
+	ListWithPaginationWithContext(ctx context.Context, subscriptionId int64, options interface{}) ([]SubscriptionNote, *Pagination, error)
+	Pager(ctx context.Context, subscriptionId int64, options SubscriptionNoteListOptions) *Pager[SubscriptionNote]
 }
 
 // SubscriptionNoteServiceOp handles communication with the subscriptionnote related methods of WooCommerce'API
 type SubscriptionNoteServiceOp struct {
-  client *Client
+	client *Client
 }
 
 // SubscriptionNoteListOption list all thee subscriptionnote list option request params
@@ -46,97 +58,117 @@ type SubscriptionNoteServiceOp struct {
 // product  integer  Limit result set to subscriptionnotes assigned a specific product.
 // dp  integer  Number of decimal points to use in each resource. Default is 2.
 type SubscriptionNoteListOptions struct {
-  ListOptions
-  Parent        []int64 `url:"parent,omitempty"`
-  ParentExclude []int64 `url:"parent_exclude,omitempty"`
+	ListOptions
+	Parent        []int64 `url:"parent,omitempty"`
+	ParentExclude []int64 `url:"parent_exclude,omitempty"`
 }
 
 // SubscriptionNoteBatchOption setting  operate for subscriptionnote in batch way
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#batch-update-subscriptionnotes
-type SubscriptionNoteBatchOption struct {
-  Create []SubscriptionNote `json:"create,omitempty"`
-  Update []SubscriptionNote `json:"update,omitempty"`
-  Delete []int64            `json:"delete,omitempty"`
-}
+type SubscriptionNoteBatchOption = BatchOption[SubscriptionNote]
 
 // SubscriptionNoteBatchResource conservation the response struct for SubscriptionNoteBatchOption request
-type SubscriptionNoteBatchResource struct {
-  Create []*SubscriptionNote `json:"create,omitempty"`
-  Update []*SubscriptionNote `json:"update,omitempty"`
-  Delete []*SubscriptionNote `json:"delete,omitempty"`
-}
+type SubscriptionNoteBatchResource = BatchResource[SubscriptionNote]
 
 // SubscriptionNote represents a WooCommerce SubscriptionNote
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#subscriptionnote-properties
 type SubscriptionNote struct {
-  ID             int64  `json:"id,omitempty"`
-  DateCreated    string `json:"date_created,omitempty"`
-  DateCreatedGmt string `json:"date_created_gmt,omitempty"`
-  Note           string `json:"note,omitempty"`
-  CustomerNote   bool   `json:"customer_note,omitempty"`
-  AddedByUser    bool   `json:"added_by_user,omitempty"`
-  Author         string `json:"author,omitempty"`
-  Links          Links  `json:"_links"`
+	ID             int64  `json:"id,omitempty"`
+	DateCreated    string `json:"date_created,omitempty"`
+	DateCreatedGmt string `json:"date_created_gmt,omitempty"`
+	Note           string `json:"note,omitempty"`
+	CustomerNote   bool   `json:"customer_note,omitempty"`
+	AddedByUser    bool   `json:"added_by_user,omitempty"`
+	Author         string `json:"author,omitempty"`
+	Links          Links  `json:"_links"`
+
+	// Extra keeps the fields not modelled above, such as those of plugins.
+	Extra Extra `json:"-"`
+}
+
+// UnmarshalJSON decodes n, keeping the fields it does not model in Extra.
+func (n *SubscriptionNote) UnmarshalJSON(data []byte) error {
+	type plain SubscriptionNote
+	return unmarshalExtra(data, (*plain)(n), &n.Extra)
+}
+
+// MarshalJSON encodes n along with its Extra fields.
+func (n SubscriptionNote) MarshalJSON() ([]byte, error) {
+	type plain SubscriptionNote
+	return marshalExtra(plain(n), n.Extra)
+}
+
+func (o *SubscriptionNoteServiceOp) resource(subscriptionId int64) *Resource[SubscriptionNote, SubscriptionNoteListOptions] {
+	return NewResource[SubscriptionNote, SubscriptionNoteListOptions](o.client, fmt.Sprintf(subscriptionNotesBasePath, subscriptionId), func(v *SubscriptionNote) int64 { return v.ID })
 }
 
 func (o *SubscriptionNoteServiceOp) List(subscriptionId int64, options interface{}) ([]SubscriptionNote, error) {
-  subscriptionnotes, _, err := o.ListWithPagination(subscriptionId, options)
-  return subscriptionnotes, err
+	return o.ListWithContext(context.Background(), subscriptionId, options)
+}
+
+func (o *SubscriptionNoteServiceOp) ListWithContext(ctx context.Context, subscriptionId int64, options interface{}) ([]SubscriptionNote, error) {
+	return o.resource(subscriptionId).ListWithContext(ctx, options)
 }
 
 // ListWithPagination lists products and return pagination to retrieve next/previous results.
 func (o *SubscriptionNoteServiceOp) ListWithPagination(subscriptionId int64, options interface{}) ([]SubscriptionNote, *Pagination, error) {
-  resource := make([]SubscriptionNote, 0)
-  basePath := fmt.Sprintf(subscriptionNotesBasePath, subscriptionId)
-  headers, err := o.client.createAndDoGetHeaders("GET", basePath, nil, options, &resource)
-  if err != nil {
-    return nil, nil, err
-  }
-  // Extract pagination info from header
-  pagination, err := extractPagination(headers)
-  if err != nil {
-    return nil, nil, err
-  }
+	return o.ListWithPaginationWithContext(context.Background(), subscriptionId, options)
+}
+
+func (o *SubscriptionNoteServiceOp) ListWithPaginationWithContext(ctx context.Context, subscriptionId int64, options interface{}) ([]SubscriptionNote, *Pagination, error) {
+	return o.resource(subscriptionId).ListWithPaginationWithContext(ctx, options)
+}
 
-  return resource, pagination, err
+// Pager returns a Pager over the notes of a subscription.
+func (o *SubscriptionNoteServiceOp) Pager(ctx context.Context, subscriptionId int64, options SubscriptionNoteListOptions) *Pager[SubscriptionNote] {
+	return o.resource(subscriptionId).Pager(ctx, options)
 }
 
 func (o *SubscriptionNoteServiceOp) Create(subscriptionId int64, text string) (*SubscriptionNote, error) {
-  basePath := fmt.Sprintf(subscriptionNotesBasePath, subscriptionId)
-  resource := new(SubscriptionNote)
-  subscriptionNote := SubscriptionNote{
-    Note: text,
-  }
+	return o.CreateWithContext(context.Background(), subscriptionId, text)
+}
 
-  err := o.client.Post(basePath, &subscriptionNote, resource)
-  return resource, err
+func (o *SubscriptionNoteServiceOp) CreateWithContext(ctx context.Context, subscriptionId int64, text string) (*SubscriptionNote, error) {
+	return o.resource(subscriptionId).CreateWithContext(ctx, SubscriptionNote{Note: text})
 }
 
 // Get individual subscriptionnote
 func (o *SubscriptionNoteServiceOp) Get(subscriptionId int64, subscriptionNoteID int64, options interface{}) (*SubscriptionNote, error) {
-  path := fmt.Sprintf("%s/%d", fmt.Sprintf(subscriptionNotesBasePath, subscriptionId), subscriptionNoteID)
-  resource := new(SubscriptionNote)
-  err := o.client.Get(path, resource, options)
-	return resource, err
+	return o.GetWithContext(context.Background(), subscriptionId, subscriptionNoteID, options)
+}
+
+func (o *SubscriptionNoteServiceOp) GetWithContext(ctx context.Context, subscriptionId int64, subscriptionNoteID int64, options interface{}) (*SubscriptionNote, error) {
+	return o.resource(subscriptionId).GetWithContext(ctx, subscriptionNoteID, options)
 }
 
 func (o *SubscriptionNoteServiceOp) Update(subscriptionId int64, subscriptionnote *SubscriptionNote) (*SubscriptionNote, error) {
-	path := fmt.Sprintf("%s/%d", fmt.Sprintf(subscriptionNotesBasePath, subscriptionId), subscriptionnote.ID)
-	resource := new(SubscriptionNote)
-	err := o.client.Put(path, subscriptionnote, &resource)
-	return resource, err
+	return o.UpdateWithContext(context.Background(), subscriptionId, subscriptionnote)
+}
+
+func (o *SubscriptionNoteServiceOp) UpdateWithContext(ctx context.Context, subscriptionId int64, subscriptionnote *SubscriptionNote) (*SubscriptionNote, error) {
+	return o.resource(subscriptionId).UpdateWithContext(ctx, subscriptionnote)
+}
+
+func (o *SubscriptionNoteServiceOp) Patch(subscriptionId int64, subscriptionNote *SubscriptionNote, fields ...string) (*SubscriptionNote, error) {
+	return o.PatchWithContext(context.Background(), subscriptionId, subscriptionNote, fields...)
+}
+
+func (o *SubscriptionNoteServiceOp) PatchWithContext(ctx context.Context, subscriptionId int64, subscriptionNote *SubscriptionNote, fields ...string) (*SubscriptionNote, error) {
+	return o.resource(subscriptionId).PatchWithContext(ctx, subscriptionNote, fields...)
 }
 
 func (o *SubscriptionNoteServiceOp) Delete(subscriptionId int64, subscriptionnoteID int64, options interface{}) (*SubscriptionNote, error) {
-	path := fmt.Sprintf("%s/%d", fmt.Sprintf(subscriptionNotesBasePath, subscriptionId), subscriptionnoteID)
-	resource := new(SubscriptionNote)
-	err := o.client.Delete(path, options, &resource)
-	return resource, err
+	return o.DeleteWithContext(context.Background(), subscriptionId, subscriptionnoteID, options)
+}
+
+func (o *SubscriptionNoteServiceOp) DeleteWithContext(ctx context.Context, subscriptionId int64, subscriptionnoteID int64, options interface{}) (*SubscriptionNote, error) {
+	return o.resource(subscriptionId).DeleteWithContext(ctx, subscriptionnoteID, options)
 }
 
 func (o *SubscriptionNoteServiceOp) Batch(subscriptionId int64, data SubscriptionNoteBatchOption) (*SubscriptionNoteBatchResource, error) {
-	path := fmt.Sprintf("%s/batch", fmt.Sprintf(subscriptionNotesBasePath, subscriptionId))
-	resource := new(SubscriptionNoteBatchResource)
-	err := o.client.Post(path, data, &resource)
-	return resource, err
+	return o.BatchWithContext(context.Background(), subscriptionId, data)
+}
+
+func (o *SubscriptionNoteServiceOp) BatchWithContext(ctx context.Context, subscriptionId int64, data SubscriptionNoteBatchOption) (*SubscriptionNoteBatchResource, error) {
+	return o.resource(subscriptionId).BatchWithContext(ctx, data)
 }
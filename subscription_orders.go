@@ -1,6 +1,7 @@
 package woocommerce
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -15,8 +16,19 @@ type SubscriptionOrderService interface {
 	Get(subscriptionId int64, orderId int64, options interface{}) (*Order, error)
 	List(subscriptionId int64, options SubscriptionOrderListOptions) ([]Order, error)
 	Update(subscriptionId int64, order *Order) (*Order, error)
+	Patch(subscriptionId int64, order *Order, fields ...string) (*Order, error)
 	Delete(subscriptionId int64, subscriptioNorderID int64, options interface{}) (*Order, error)
 	Batch(subscriptionId int64, option SubscriptionOrderBatchOption) (*SubscriptionOrderBatchResource, error)
+	ListWithPagination(subscriptionId int64, options interface{}) ([]Order, *Pagination, error)
+	CreateWithContext(ctx context.Context, subscriptionId int64, order Order) (*Order, error)
+	GetWithContext(ctx context.Context, subscriptionId int64, orderId int64, options interface{}) (*Order, error)
+	ListWithContext(ctx context.Context, subscriptionId int64, options SubscriptionOrderListOptions) ([]Order, error)
+	UpdateWithContext(ctx context.Context, subscriptionId int64, order *Order) (*Order, error)
+	PatchWithContext(ctx context.Context, subscriptionId int64, order *Order, fields ...string) (*Order, error)
+	DeleteWithContext(ctx context.Context, subscriptionId int64, subscriptioNorderID int64, options interface{}) (*Order, error)
+	BatchWithContext(ctx context.Context, subscriptionId int64, option SubscriptionOrderBatchOption) (*SubscriptionOrderBatchResource, error)
+	ListWithPaginationWithContext(ctx context.Context, subscriptionId int64, options interface{}) ([]Order, *Pagination, error)
+	Pager(ctx context.Context, subscriptionId int64, options SubscriptionOrderListOptions) *Pager[Order]
 }
 
 // SubscriptionOrderServiceOp handles communication with the order related methods of WooCommerce'API
@@ -52,79 +64,87 @@ type SubscriptionOrderListOptions struct {
 	Status        []string `url:"status,omitempty"`
 	Customer      int64    `url:"customer,omitempty"`
 	Product       int64    `url:"product,omitempty"`
-	Dp            int      `url:"id,omitempty"`
+	Dp            int      `url:"dp,omitempty"`
 }
 
 // SubscriptionOrderBatchOption setting  operate for order in batch way
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#batch-update-subscriptionorders
-type SubscriptionOrderBatchOption struct {
-	Create []Order `json:"create,omitempty"`
-	Update []Order `json:"update,omitempty"`
-	Delete []int64 `json:"delete,omitempty"`
-}
+type SubscriptionOrderBatchOption = BatchOption[Order]
 
 // SubscriptionOrderBatchResource conservation the response struct for SubscriptionOrderBatchOption request
-type SubscriptionOrderBatchResource struct {
-	Create []*Order `json:"create,omitempty"`
-	Update []*Order `json:"update,omitempty"`
-	Delete []*Order `json:"delete,omitempty"`
+type SubscriptionOrderBatchResource = BatchResource[Order]
+
+func (o *SubscriptionOrderServiceOp) resource(subscriptionId int64) *Resource[Order, SubscriptionOrderListOptions] {
+	return NewResource[Order, SubscriptionOrderListOptions](o.client, fmt.Sprintf(subscriptionOrdersBasePath, subscriptionId), func(v *Order) int64 { return v.ID })
 }
 
 func (o *SubscriptionOrderServiceOp) List(subscriptionId int64, options SubscriptionOrderListOptions) ([]Order, error) {
-	subscriptionorders, _, err := o.ListWithPagination(subscriptionId, options)
-	return subscriptionorders, err
+	return o.ListWithContext(context.Background(), subscriptionId, options)
+}
+
+func (o *SubscriptionOrderServiceOp) ListWithContext(ctx context.Context, subscriptionId int64, options SubscriptionOrderListOptions) ([]Order, error) {
+	return o.resource(subscriptionId).ListWithContext(ctx, options)
 }
 
 // ListWithPagination lists products and return pagination to retrieve next/previous results.
 func (o *SubscriptionOrderServiceOp) ListWithPagination(subscriptionId int64, options interface{}) ([]Order, *Pagination, error) {
-	resource := make([]Order, 0)
-	basePath := fmt.Sprintf(subscriptionOrdersBasePath, subscriptionId)
-	headers, err := o.client.createAndDoGetHeaders("GET", basePath, nil, options, &resource)
-	if err != nil {
-		return nil, nil, err
-	}
-	// Extract pagination info from header
-	pagination, err := extractPagination(headers)
-	if err != nil {
-		return nil, nil, err
-	}
+	return o.ListWithPaginationWithContext(context.Background(), subscriptionId, options)
+}
 
-	return resource, pagination, err
+func (o *SubscriptionOrderServiceOp) ListWithPaginationWithContext(ctx context.Context, subscriptionId int64, options interface{}) ([]Order, *Pagination, error) {
+	return o.resource(subscriptionId).ListWithPaginationWithContext(ctx, options)
+}
+
+// Pager returns a Pager over the orders of a subscription.
+func (o *SubscriptionOrderServiceOp) Pager(ctx context.Context, subscriptionId int64, options SubscriptionOrderListOptions) *Pager[Order] {
+	return o.resource(subscriptionId).Pager(ctx, options)
 }
 
 func (o *SubscriptionOrderServiceOp) Create(subscriptionId int64, order Order) (*Order, error) {
-	basePath := fmt.Sprintf(subscriptionOrdersBasePath, subscriptionId)
-	resource := new(Order)
+	return o.CreateWithContext(context.Background(), subscriptionId, order)
+}
 
-	err := o.client.Post(basePath, &order, resource)
-	return resource, err
+func (o *SubscriptionOrderServiceOp) CreateWithContext(ctx context.Context, subscriptionId int64, order Order) (*Order, error) {
+	return o.resource(subscriptionId).CreateWithContext(ctx, order)
 }
 
 // Get individual order
 func (o *SubscriptionOrderServiceOp) Get(subscriptionId int64, orderID int64, options interface{}) (*Order, error) {
-	path := fmt.Sprintf("%s/%d", fmt.Sprintf(subscriptionOrdersBasePath, subscriptionId), orderID)
-	resource := new(Order)
-	err := o.client.Get(path, resource, options)
-	return resource, err
+	return o.GetWithContext(context.Background(), subscriptionId, orderID, options)
+}
+
+func (o *SubscriptionOrderServiceOp) GetWithContext(ctx context.Context, subscriptionId int64, orderID int64, options interface{}) (*Order, error) {
+	return o.resource(subscriptionId).GetWithContext(ctx, orderID, options)
 }
 
 func (o *SubscriptionOrderServiceOp) Update(subscriptionId int64, order *Order) (*Order, error) {
-	path := fmt.Sprintf("%s/%d", fmt.Sprintf(subscriptionOrdersBasePath, subscriptionId), order.ID)
-	resource := new(Order)
-	err := o.client.Put(path, order, &resource)
-	return resource, err
+	return o.UpdateWithContext(context.Background(), subscriptionId, order)
+}
+
+func (o *SubscriptionOrderServiceOp) UpdateWithContext(ctx context.Context, subscriptionId int64, order *Order) (*Order, error) {
+	return o.resource(subscriptionId).UpdateWithContext(ctx, order)
+}
+
+func (o *SubscriptionOrderServiceOp) Patch(subscriptionId int64, order *Order, fields ...string) (*Order, error) {
+	return o.PatchWithContext(context.Background(), subscriptionId, order, fields...)
+}
+
+func (o *SubscriptionOrderServiceOp) PatchWithContext(ctx context.Context, subscriptionId int64, order *Order, fields ...string) (*Order, error) {
+	return o.resource(subscriptionId).PatchWithContext(ctx, order, fields...)
 }
 
 func (o *SubscriptionOrderServiceOp) Delete(subscriptionId int64, subscriptionorderID int64, options interface{}) (*Order, error) {
-	path := fmt.Sprintf("%s/%d", fmt.Sprintf(subscriptionOrdersBasePath, subscriptionId), subscriptionorderID)
-	resource := new(Order)
-	err := o.client.Delete(path, options, &resource)
-	return resource, err
+	return o.DeleteWithContext(context.Background(), subscriptionId, subscriptionorderID, options)
+}
+
+func (o *SubscriptionOrderServiceOp) DeleteWithContext(ctx context.Context, subscriptionId int64, subscriptionorderID int64, options interface{}) (*Order, error) {
+	return o.resource(subscriptionId).DeleteWithContext(ctx, subscriptionorderID, options)
 }
 
 func (o *SubscriptionOrderServiceOp) Batch(subscriptionId int64, data SubscriptionOrderBatchOption) (*SubscriptionOrderBatchResource, error) {
-	path := fmt.Sprintf("%s/batch", fmt.Sprintf(subscriptionOrdersBasePath, subscriptionId))
-	resource := new(SubscriptionOrderBatchResource)
-	err := o.client.Post(path, data, &resource)
-	return resource, err
+	return o.BatchWithContext(context.Background(), subscriptionId, data)
+}
+
+func (o *SubscriptionOrderServiceOp) BatchWithContext(ctx context.Context, subscriptionId int64, data SubscriptionOrderBatchOption) (*SubscriptionOrderBatchResource, error) {
+	return o.resource(subscriptionId).BatchWithContext(ctx, data)
 }
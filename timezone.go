@@ -0,0 +1,194 @@
+package woocommerce
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// StoreLocation returns the store's timezone, set with WithStoreTimezone or
+// LoadStoreTimezone, or UTC if it is not known.
+func (c *Client) StoreLocation() *time.Location {
+	if loc := c.location.Load(); loc != nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// LoadStoreTimezone reads the store's timezone from the WordPress settings
+// published in the REST API index, and uses it for the dates of later
+// responses.
+func (c *Client) LoadStoreTimezone() (*time.Location, error) {
+	return c.LoadStoreTimezoneWithContext(context.Background())
+}
+
+// LoadStoreTimezoneWithContext is like LoadStoreTimezone but the request is
+// bound to ctx.
+func (c *Client) LoadStoreTimezoneWithContext(ctx context.Context) (*time.Location, error) {
+	var index struct {
+		TimezoneString string      `json:"timezone_string"`
+		GMTOffset      StringFloat `json:"gmt_offset"`
+	}
+	// The index lists every route of the site; ask only for the two settings,
+	// and leave the rest of it out of drift checking.
+	fields := url.Values{"_fields": {"timezone_string,gmt_offset"}}
+	req, err := c.NewRequestWithContext(withLenientDecoding(ctx), "GET", "/wp-json/", nil, fields)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.doGetHeaders(req, &index); err != nil {
+		return nil, err
+	}
+	loc, err := storeLocation(index.TimezoneString, float64(index.GMTOffset))
+	if err != nil {
+		return nil, err
+	}
+	c.location.Store(loc)
+	return loc, nil
+}
+
+// storeLocation returns the location of a WordPress timezone setting: a
+// zone name, or an offset in hours when the site uses a manual offset.
+func storeLocation(name string, gmtOffset float64) (*time.Location, error) {
+	if name != "" {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("woocommerce: store timezone: %w", err)
+		}
+		return loc, nil
+	}
+	offset := int(gmtOffset * 3600)
+	if offset == 0 {
+		return time.UTC, nil
+	}
+	return time.FixedZone(fmt.Sprintf("UTC%+g", gmtOffset), offset), nil
+}
+
+// LocalizeTimes places the dates of v, a decoded object or list of objects,
+// in loc, the store's timezone. Dates with a *Gmt sibling field take its
+// value, which WooCommerce sends in UTC. The client does this for every
+// response; use it for objects decoded elsewhere, such as webhook payloads.
+func LocalizeTimes(v interface{}, loc *time.Location) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	walkTimes(reflect.ValueOf(v), func(st reflect.Value, local bool, gmt reflect.Value) {
+		t := time.Time(st.Interface().(StringTime))
+		if !local {
+			st.Set(reflect.ValueOf(StringTime(inLocation(t, time.UTC))))
+			return
+		}
+		if gmt.IsValid() && !gmt.Interface().(StringTime).IsZero() {
+			t = inLocation(time.Time(gmt.Interface().(StringTime)), time.UTC).In(loc)
+		} else {
+			t = inLocation(t, loc)
+		}
+		st.Set(reflect.ValueOf(StringTime(t)))
+	})
+}
+
+// inLocation returns floating t as the same wall clock in loc, and other
+// times unchanged.
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	if t.IsZero() || t.Location() != floating {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// utcTimes converts the *Gmt dates of v, a pointer, to UTC so they are
+// encoded in UTC whatever their location.
+func utcTimes(v interface{}) {
+	walkTimes(reflect.ValueOf(v), func(st reflect.Value, local bool, _ reflect.Value) {
+		if t := time.Time(st.Interface().(StringTime)); !local && !t.IsZero() && t.Location() != floating {
+			st.Set(reflect.ValueOf(StringTime(t.UTC())))
+		}
+	})
+}
+
+// storeTimes places the local dates of v, a pointer, in loc, so they are
+// encoded as the store's wall clock whatever their location. Floating dates
+// are already the store's wall clock and are left as they are.
+func storeTimes(v interface{}, loc *time.Location) {
+	walkTimes(reflect.ValueOf(v), func(st reflect.Value, local bool, _ reflect.Value) {
+		if t := time.Time(st.Interface().(StringTime)); local && !t.IsZero() && t.Location() != floating {
+			st.Set(reflect.ValueOf(StringTime(t.In(loc))))
+		}
+	})
+}
+
+// inStoreTimes returns body, a request body, with its local dates in loc.
+// Structs and the structs pointed to are copied first; the dates reached
+// through slices and maps are set in place, to the same instant.
+func inStoreTimes(body interface{}, loc *time.Location) interface{} {
+	v := reflect.ValueOf(body)
+	if v.Kind() == reflect.Pointer && !v.IsNil() && v.Elem().Kind() == reflect.Struct {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		storeTimes(body, loc)
+		return body
+	}
+	copied := reflect.New(v.Type())
+	copied.Elem().Set(v)
+	storeTimes(copied.Interface(), loc)
+	return copied.Interface()
+}
+
+// walkTimes calls fn with the settable StringTime fields reachable from v,
+// with whether they are local, rather than *Gmt, dates and their *Gmt
+// sibling if any. Local dates come after their sibling.
+func walkTimes(v reflect.Value, fn func(st reflect.Value, local bool, gmt reflect.Value)) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			walkTimes(v.Elem(), fn)
+		}
+	case reflect.Slice, reflect.Array:
+		switch v.Type().Elem().Kind() {
+		case reflect.Struct, reflect.Pointer, reflect.Slice, reflect.Interface:
+			for i := range v.Len() {
+				walkTimes(v.Index(i), fn)
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			// Map values are not settable, but what they point to is.
+			if value := iter.Value(); value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+				walkTimes(value, fn)
+			}
+		}
+	case reflect.Struct:
+		if !v.CanSet() {
+			return
+		}
+		t := v.Type()
+		var local []int
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			if f.Type != stringTimeType {
+				walkTimes(v.Field(i), fn)
+				continue
+			}
+			if strings.HasSuffix(f.Name, "Gmt") || strings.HasSuffix(f.Name, "GMT") {
+				fn(v.Field(i), false, reflect.Value{})
+				continue
+			}
+			local = append(local, i)
+		}
+		for _, i := range local {
+			gmt := v.FieldByName(t.Field(i).Name + "Gmt")
+			if gmt.IsValid() && gmt.Type() != stringTimeType {
+				gmt = reflect.Value{}
+			}
+			fn(v.Field(i), true, gmt)
+		}
+	}
+}
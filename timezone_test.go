@@ -0,0 +1,153 @@
+package woocommerce
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLocalizeTimes(t *testing.T) {
+	sp, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skip(err)
+	}
+	var orders []Order
+	err = json.Unmarshal([]byte(`[{"id":1,
+		"date_created":"2026-01-19T10:00:00","date_created_gmt":"2026-01-19T13:00:00",
+		"date_paid":"2026-01-19T10:05:00","date_paid_gmt":null,
+		"date_completed":null,"date_completed_gmt":null}]`), &orders)
+	if err != nil {
+		t.Fatal(err)
+	}
+	LocalizeTimes(&orders, sp)
+	o := orders[0]
+
+	want := time.Date(2026, 1, 19, 13, 0, 0, 0, time.UTC)
+	if created := time.Time(o.DateCreated); !created.Equal(want) || created.Location() != sp {
+		t.Errorf("date_created = %v, want %v in the store timezone", created, want)
+	}
+	if gmt := time.Time(o.DateCreatedGmt); !gmt.Equal(want) || gmt.Location() != time.UTC {
+		t.Errorf("date_created_gmt = %v", gmt)
+	}
+	if paid := time.Time(o.DatePaid); !paid.Equal(want.Add(5 * time.Minute)) {
+		t.Errorf("date_paid without gmt = %v", paid)
+	}
+	if !o.DateCompleted.IsZero() {
+		t.Errorf("date_completed = %v, want unset", time.Time(o.DateCompleted))
+	}
+
+	out, err := json.Marshal(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{`"date_created":"2026-01-19T10:00:00"`, `"date_created_gmt":"2026-01-19T13:00:00"`, `"date_completed":null`} {
+		if !strings.Contains(string(out), s) {
+			t.Errorf("%s missing from %s", s, out)
+		}
+	}
+
+	o.DateCreatedGmt = StringTime(want.In(sp))
+	if out, _ := json.Marshal(o); !strings.Contains(string(out), `"date_created_gmt":"2026-01-19T13:00:00"`) {
+		t.Errorf("gmt date not encoded in UTC: %s", out)
+	}
+}
+
+func TestClient_StoreTimezone(t *testing.T) {
+	if _, err := time.LoadLocation("Asia/Kolkata"); err != nil {
+		t.Skip(err)
+	}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wp-json/":
+			if fields := r.URL.Query().Get("_fields"); fields != "timezone_string,gmt_offset" {
+				t.Errorf("index requested with _fields=%q", fields)
+			}
+			io.WriteString(w, `{"name":"Shop","gmt_offset":"5.5","timezone_string":"Asia/Kolkata","namespaces":["wc/v3"]}`)
+		default:
+			io.WriteString(w, `{"id":1,"date_created":"2026-01-19T10:00:00","date_modified":"2026-01-19T10:00:00","date_modified_gmt":"2026-01-19T04:30:00"}`)
+		}
+	}), WithDecodeMode(DecodeStrict))
+	ctx := context.Background()
+	if loc := c.StoreLocation(); loc != time.UTC {
+		t.Errorf("default location %v", loc)
+	}
+	loc, err := c.LoadStoreTimezoneWithContext(ctx)
+	if err != nil || loc.String() != "Asia/Kolkata" || c.StoreLocation() != loc {
+		t.Fatalf("location %v, %v", loc, err)
+	}
+
+	order, err := c.Order.GetWithContext(ctx, 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2026, 1, 19, 4, 30, 0, 0, time.UTC)
+	if !time.Time(order.DateCreated).Equal(want) || !time.Time(order.DateModified).Equal(want) {
+		t.Errorf("dates %v, %v, want %v", time.Time(order.DateCreated), time.Time(order.DateModified), want)
+	}
+
+	fixed := NewClient(App{}, "https://shop.example.com", WithStoreTimezone(time.FixedZone("BRT", -3*3600)))
+	if fixed.StoreLocation().String() != "BRT" {
+		t.Errorf("WithStoreTimezone location %v", fixed.StoreLocation())
+	}
+}
+
+func TestStoreLocation(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		offset float64
+		want   string
+	}{
+		{"", 0, "UTC"},
+		{"", -3, "UTC-3"},
+		{"", 5.75, "UTC+5.75"},
+	} {
+		loc, err := storeLocation(tt.name, tt.offset)
+		if err != nil || loc.String() != tt.want {
+			t.Errorf("storeLocation(%q, %v) = %v, %v", tt.name, tt.offset, loc, err)
+		}
+	}
+	if _, err := storeLocation("Nowhere/Special", 0); err == nil {
+		t.Error("expected an error for an unknown zone")
+	}
+	if loc, _ := storeLocation("", 5.75); time.Date(2026, 1, 1, 0, 0, 0, 0, loc).Format("-07:00") != "+05:45" {
+		t.Error("fractional offset lost")
+	}
+}
+
+func TestClient_StoreTimesInRequests(t *testing.T) {
+	var sent map[string]json.RawMessage
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sent = nil
+		json.Unmarshal(body, &sent)
+		io.WriteString(w, `{"id":3}`)
+	}), WithStoreTimezone(time.FixedZone("BRT", -3*3600)))
+	ctx := context.Background()
+
+	utc := time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC)
+	coupon := &Coupon{ID: 3, DateExpires: StringTime(utc), DateExpiresGmt: StringTime(utc)}
+	if _, err := c.Coupon.UpdateWithContext(ctx, coupon); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(sent["date_expires"]); got != `"2026-03-01T12:00:00"` {
+		t.Errorf("date_expires = %s, want the store's wall clock", got)
+	}
+	if got := string(sent["date_expires_gmt"]); got != `"2026-03-01T15:00:00"` {
+		t.Errorf("date_expires_gmt = %s", got)
+	}
+	if time.Time(coupon.DateExpires).Location() != time.UTC {
+		t.Error("request changed the caller's date")
+	}
+
+	product := &Product{ID: 3, DateOnSaleFrom: StringTime(utc)}
+	if _, err := c.Product.PatchWithContext(ctx, product, "date_on_sale_from"); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(sent["date_on_sale_from"]); got != `"2026-03-01T12:00:00"` {
+		t.Errorf("patched date_on_sale_from = %s", got)
+	}
+}
@@ -1,7 +1,7 @@
 package woocommerce
 
 import (
-	"fmt"
+	"context"
 )
 
 const (
@@ -16,13 +16,25 @@ type WebhookService interface {
 	Create(webhook Webhook) (*Webhook, error)
 	Get(webhookID int64, options interface{}) (*Webhook, error)
 	Update(webhook *Webhook) (*Webhook, error)
+	Patch(webhook *Webhook, fields ...string) (*Webhook, error)
 	Delete(webhookID int64, options interface{}) (*Webhook, error)
 	Batch(data WebhookBatchOption) (*WebhookBatchResource, error)
+	ListWithContext(ctx context.Context, options interface{}) ([]Webhook, error)
+	CreateWithContext(ctx context.Context, webhook Webhook) (*Webhook, error)
+	GetWithContext(ctx context.Context, webhookID int64, options interface{}) (*Webhook, error)
+	UpdateWithContext(ctx context.Context, webhook *Webhook) (*Webhook, error)
+	PatchWithContext(ctx context.Context, webhook *Webhook, fields ...string) (*Webhook, error)
+	DeleteWithContext(ctx context.Context, webhookID int64, options interface{}) (*Webhook, error)
+	BatchWithContext(ctx context.Context, data WebhookBatchOption) (*WebhookBatchResource, error)
+	ListWithPagination(options interface{}) ([]Webhook, *Pagination, error)
+	ListWithPaginationWithContext(ctx context.Context, options interface{}) ([]Webhook, *Pagination, error)
+	Pager(ctx context.Context, options WebhookListOption) *Pager[Webhook]
+	Base() *Resource[Webhook, WebhookListOption]
 }
 
 // WebhookServiceOp handles communication with the webhooks related methods of WooCommerce restful api
 type WebhookServiceOp struct {
-	client *Client
+	*Resource[Webhook, WebhookListOption]
 }
 
 // Webhook represent a  wooCommerce webhook's All  properties columns
@@ -41,6 +53,22 @@ type Webhook struct {
 	DateModified    string   `json:"date_modified,omitempty"`
 	DateModifiedGmt string   `json:"date_modified_gmt,omitempty"`
 	Links           Links    `json:"_links,omitempty"`
+	Embedded        Embedded `json:"_embedded,omitempty"`
+
+	// Extra keeps the fields not modelled above, such as those of plugins.
+	Extra Extra `json:"-"`
+}
+
+// UnmarshalJSON decodes w, keeping the fields it does not model in Extra.
+func (w *Webhook) UnmarshalJSON(data []byte) error {
+	type plain Webhook
+	return unmarshalExtra(data, (*plain)(w), &w.Extra)
+}
+
+// MarshalJSON encodes w along with its Extra fields.
+func (w Webhook) MarshalJSON() ([]byte, error) {
+	type plain Webhook
+	return marshalExtra(plain(w), w.Extra)
 }
 
 // WebhookListOption config webhook's List method request option
@@ -55,72 +83,7 @@ type WebhookDeleteOption struct {
 }
 
 // OrderBatchOption setting  operate for order in batch way
-type WebhookBatchOption struct {
-	Create []Webhook `json:"create,omitempty"`
-	Update []Webhook `json:"update,omitempty"`
-	Delete []int64   `json:"delete,omitempty"`
-}
+type WebhookBatchOption = BatchOption[Webhook]
 
 // WebhookBatchResource conservation the response struct for WebhookBatchOption's request
-type WebhookBatchResource struct {
-	Create []*Webhook `json:"create,omitempty"`
-	Update []*Webhook `json:"update,omitempty"`
-	Delete []*Webhook `json:"delete,omitempty"`
-}
-
-// List return multiple webhooks
-// https://woocommerce.github.io/woocommerce-rest-api-docs/#list-all-webhooks
-func (w *WebhookServiceOp) List(options interface{}) ([]Webhook, error) {
-	path := fmt.Sprintf("%s", webhooksBasePath)
-	resource := make([]Webhook, 0)
-	err := w.client.Get(path, &resource, options)
-	return resource, err
-}
-
-// Create handle create a new webhook.
-// https://woocommerce.github.io/woocommerce-rest-api-docs/#create-a-webhook
-func (w *WebhookServiceOp) Create(webhook Webhook) (*Webhook, error) {
-	path := fmt.Sprintf("%s", webhooksBasePath)
-	resource := new(Webhook)
-	err := w.client.Post(path, webhook, &resource)
-	return resource, err
-}
-
-// Get implement for retrieve and view a specific webhook
-// https://woocommerce.github.io/woocommerce-rest-api-docs/#retrieve-a-webhook
-func (w *WebhookServiceOp) Get(webhookID int64, options interface{}) (*Webhook, error) {
-	path := fmt.Sprintf("%s/%d", webhooksBasePath, webhookID)
-	resource := new(Webhook)
-	err := w.client.Get(path, &resource, options)
-	return resource, err
-}
-
-// Update method allow you to make changes to a webhook
-// https://woocommerce.github.io/woocommerce-rest-api-docs/#update-a-webhook
-func (w *WebhookServiceOp) Update(webhook *Webhook) (*Webhook, error) {
-	path := fmt.Sprintf("%s/%d", webhooksBasePath, webhook.ID)
-	resource := new(Webhook)
-	err := w.client.Put(path, webhook, &resource)
-
-	return resource, err
-}
-
-// Delete delete a webhook
-// https://woocommerce.github.io/woocommerce-rest-api-docs/#delete-a-webhook
-func (w *WebhookServiceOp) Delete(webhookID int64, options interface{}) (*Webhook, error) {
-	path := fmt.Sprintf("%s/%d", webhooksBasePath, webhookID)
-	resource := new(Webhook)
-	err := w.client.Delete(path, options, &resource)
-	return resource, err
-}
-
-// Batch helps you to batch create, update and delete multiple webhooks
-// WooCommerce docs Notes : By default it's limited to up to 100 objects to be created, updated or deleted.
-// reference :
-// https://woocommerce.github.io/woocommerce-rest-api-docs/#batch-update-webhooks
-func (w *WebhookServiceOp) Batch(data WebhookBatchOption) (*WebhookBatchResource, error) {
-	path := fmt.Sprintf("%s/batch", webhooksBasePath)
-	resource := new(WebhookBatchResource)
-	err := w.client.Post(path, data, &resource)
-	return resource, err
-}
+type WebhookBatchResource = BatchResource[Webhook]
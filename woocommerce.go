@@ -2,18 +2,23 @@ package woocommerce
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"maps"
 	"net/http"
 	"net/url"
 	"path"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
-
-	"github.com/google/go-querystring/query"
 )
 
 const (
@@ -39,12 +44,18 @@ type App struct {
 	Client         *Client
 }
 
+// RateLimitInfo is the rate limit last reported by the store, see
+// Client.CurrentRateLimits. RequestCount is the number of requests already
+// used out of BucketSize in the current window.
 type RateLimitInfo struct {
 	RequestCount      int
 	BucketSize        int
 	RetryAfterSeconds float64
 }
 
+// Client is safe for concurrent use by multiple goroutines once configured;
+// per call details such as the number of attempts are reported through
+// ContextWithCallInfo rather than stored on the Client.
 type Client struct {
 	Client     *http.Client
 	app        App
@@ -54,22 +65,51 @@ type Client struct {
 	pathPrefix string
 	// token      string
 
-	// max number of retries, defaults to 0 for no retries see WithRetry option
-	retries  int
-	attempts int
-
-	File              FileService
-	Customer          CustomerService
-	RateLimits        RateLimitInfo
-	Product           ProductService
-	Order             OrderService
-	OrderNote         OrderNoteService
-	Webhook           WebhookService
-	PaymentGateway    PaymentGatewayService
-	Subscription      SubscriptionService
-	SubscriptionNote  SubscriptionNoteService
-	SubscriptionOrder SubscriptionOrderService
-	Coupon            CouponService
+	// oauth1 signs requests with OAuth 1.0a instead of Basic auth, see WithOAuth1 option
+	oauth1 *oauth1Signer
+
+	// retry policy, defaults to no retries see WithRetry and WithRetryPolicy options
+	retry RetryPolicy
+
+	// limiter throttles requests, see WithRateLimit and WithRateLimiter options
+	limiter      *RateLimiter
+	rateLimitsMu sync.Mutex
+
+	// middleware wraps every attempt, see WithMiddleware option
+	middleware []Middleware
+
+	// instrumentation receives metrics and span hooks, see WithInstrumentation option
+	instrumentation Instrumentation
+
+	// redaction masks secrets and personal data in logs, see WithRedaction option
+	redaction Redaction
+
+	// decodeMode checks responses for schema drift, see WithDecodeMode option
+	decodeMode DecodeMode
+
+	// location is the store's timezone, see WithStoreTimezone option and LoadStoreTimezone
+	location atomic.Pointer[time.Location]
+
+	// dryRun records the POST, PUT and DELETE requests instead of sending them, see WithDryRun option
+	dryRun *DryRun
+
+	File                 FileService
+	Customer             CustomerService
+	RateLimits           RateLimitInfo // updated from every response reporting a rate limit
+	Product              ProductService
+	Order                OrderService
+	OrderNote            OrderNoteService
+	Webhook              WebhookService
+	PaymentGateway       PaymentGatewayService
+	Subscription         SubscriptionService
+	SubscriptionNote     SubscriptionNoteService
+	SubscriptionOrder    SubscriptionOrderService
+	Coupon               CouponService
+	ProductCategory      ProductCategoryService
+	ProductTag           ProductTagService
+	ProductAttribute     ProductAttributeService
+	ProductReview        ProductReviewService
+	ProductShippingClass ProductShippingClassService
 }
 
 // NewClient returns a new WooCommerce API client with an already authenticated shopname and
@@ -98,18 +138,27 @@ func NewClient(app App, shopName string, opts ...Option) *Client {
 		baseURL:    baseURL,
 		version:    defaultVersion,
 		pathPrefix: defaultApiPathPrefix,
+		retry:      noRetryPolicy,
+		redaction:  DefaultRedaction(),
+
+		instrumentation: NopInstrumentation{},
 	}
-	c.Customer = &CustomerServiceOp{client: c}
-	c.Product = &ProductServiceOp{client: c}
-	c.Order = &OrderServiceOp{client: c}
+	c.Customer = &CustomerServiceOp{NewResource[Customer, CustomerListOption](c, customersBasePath, func(v *Customer) int64 { return v.ID })}
+	c.Product = &ProductServiceOp{NewResource[Product, ProductListOptions](c, productsBasePath, func(v *Product) int64 { return v.ID })}
+	c.Order = &OrderServiceOp{NewResource[Order, OrderListOption](c, ordersBasePath, func(v *Order) int64 { return v.ID })}
 	c.OrderNote = &OrderNoteServiceOp{client: c}
 	c.File = &FileServiceOp{Client: c}
-	c.Webhook = &WebhookServiceOp{client: c}
+	c.Webhook = &WebhookServiceOp{NewResource[Webhook, WebhookListOption](c, webhooksBasePath, func(v *Webhook) int64 { return v.ID })}
 	c.PaymentGateway = &PaymentGatewayServiceOp{client: c}
-	c.Subscription = &SubscriptionServiceOp{client: c}
+	c.Subscription = &SubscriptionServiceOp{NewResource[Subscription, SubscriptionListOptions](c, subscriptionsBasePath, func(v *Subscription) int64 { return v.ID })}
 	c.SubscriptionNote = &SubscriptionNoteServiceOp{client: c}
 	c.SubscriptionOrder = &SubscriptionOrderServiceOp{client: c}
-	c.Coupon = &CouponServiceOp{client: c}
+	c.Coupon = &CouponServiceOp{NewResource[Coupon, CouponListOption](c, couponsBasePath, func(v *Coupon) int64 { return v.ID })}
+	c.ProductCategory = &ProductCategoryServiceOp{NewResource[ProductCategory, ProductCategoryListOption](c, productCategoriesBasePath, func(v *ProductCategory) int64 { return v.ID })}
+	c.ProductTag = &ProductTagServiceOp{NewResource[ProductTag, ProductTagListOption](c, productTagsBasePath, func(v *ProductTag) int64 { return v.ID })}
+	c.ProductAttribute = &ProductAttributeServiceOp{NewResource[ProductAttributeData, ProductAttributeListOption](c, productAttributesBasePath, func(v *ProductAttributeData) int64 { return v.ID })}
+	c.ProductReview = &ProductReviewServiceOp{NewResource[ProductReview, ProductReviewListOption](c, productReviewsBasePath, func(v *ProductReview) int64 { return v.ID })}
+	c.ProductShippingClass = &ProductShippingClassServiceOp{NewResource[ProductShippingClass, ProductShippingClassListOption](c, productShippingClassesBasePath, func(v *ProductShippingClass) int64 { return v.ID })}
 	for _, opt := range opts {
 		opt(c)
 	}
@@ -134,80 +183,190 @@ func (c *Client) Do(req *http.Request, v interface{}) error {
 	return nil
 }
 
+// DoWithContext is like Do but binds the request to ctx, so cancellation and
+// deadlines apply to the HTTP call as well as to any retry waits.
+func (c *Client) DoWithContext(ctx context.Context, req *http.Request, v interface{}) error {
+	return c.Do(req.WithContext(ctx), v)
+}
+
 // doGetHeaders executes a request, decoding the response into `v` and also returns any response headers.
+// The request context bounds both the HTTP round trips and the waits between retries.
 func (c *Client) doGetHeaders(req *http.Request, v interface{}) (http.Header, error) {
+	endpoint := c.endpoint(req)
+	ctx := context.WithValue(req.Context(), endpointKey{}, endpoint)
+	ctx = c.instrumentation.StartCall(ctx, endpoint)
+
+	var call CallInfo
+	start := time.Now()
+	headers, err := c.do(req.WithContext(ctx), endpoint, v, &call)
+	call.Duration = time.Since(start)
+	recordCallInfo(ctx, call)
+	c.instrumentation.EndCall(ctx, endpoint, call, err)
+	return headers, err
+}
+
+// do runs the retry loop of doGetHeaders, recording attempts and the last
+// status in call.
+func (c *Client) do(req *http.Request, endpoint Endpoint, v interface{}, call *CallInfo) (http.Header, error) {
 	var resp *http.Response
 	var err error
-	retries := c.retries
-	c.attempts = 0
-	c.logRequest(req)
+	ctx := req.Context()
+	log := logWith(c.log, requestAttrs(req.Method, endpoint)...)
+	send := c.handler()
+	policy := &c.retry
+	start := policy.Clock.Now()
+	attempts := 0
+	defer func() { call.Attempts = attempts }()
+	c.logRequest(log, req)
 
 	for {
-		c.attempts++
-		start := time.Now()
-		resp, err = c.Client.Do(req)
-		duration := time.Since(start)
-
-		if err != nil {
-			c.log.Errorf("HTTP Error (took %s): %v", duration, err)
-			return nil, err //http client errors, not api responses
+		if attempts > 0 {
+			if err := c.rewindRequest(req); err != nil {
+				return nil, err
+			}
 		}
-
-		respErr := CheckResponseError(resp)
-		if respErr == nil {
-			break // no errors, break out of the retry loop
+		if c.limiter != nil {
+			waited, err := c.limiter.wait(ctx)
+			if waited > 0 {
+				c.instrumentation.RateLimitWait(ctx, endpoint, waited)
+			}
+			if err != nil {
+				return nil, err
+			}
 		}
-		c.log.Errorf("API error (took %s) %v", duration, respErr)
+		attempts++
+		begin := time.Now()
+		resp, err = send(req)
+		duration := time.Since(begin)
+		if resp == nil && err == nil {
+			err = errNoResponse
+		}
+		log := logWith(log, slog.Int("attempt", attempts), slog.Duration("duration", duration))
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+			call.StatusCode = status
+			c.updateRateLimits(resp)
+			log = logWith(log, slog.Int("status", status))
+		}
+		c.instrumentation.Attempt(ctx, endpoint, attempts, status, duration, err)
 
-		// retry scenario, close resp and any continue will retry
-		resp.Body.Close()
+		var lastErr error
+		var retryAfter time.Duration
+		if err != nil {
+			log.Errorf("HTTP Error (took %s): %v", duration, err)
+			if !policy.retryError(req.Method, err) {
+				return nil, err //http client errors, not api responses
+			}
+			lastErr = err
+		} else {
+			respErr := checkResponseError(resp, log, &c.redaction)
+			if respErr == nil {
+				break // no errors, break out of the retry loop
+			}
+			log.Errorf("API error (took %s) %v", duration, respErr)
 
-		if retries <= 1 {
-			return nil, respErr
-		}
+			// retry scenario, close resp and any continue will retry
+			resp.Body.Close()
 
-		if rateLimitErr, isRetryErr := respErr.(RateLimitError); isRetryErr {
-			wait := time.Duration(rateLimitErr.RetryAfter) * time.Second
-			c.log.Debugf("rate limited waiting %s", wait.String())
-			time.Sleep(wait)
-			retries--
-			continue
+			if !policy.retryStatus(req.Method, resp.StatusCode) {
+				return nil, respErr
+			}
+			lastErr = respErr
+			retryAfter = parseRetryAfter(resp.Header, policy.Clock.Now())
 		}
 
-		var doRetry bool
-		switch resp.StatusCode {
-		case http.StatusServiceUnavailable:
-			c.log.Debugf("service unavailable, retrying")
-			doRetry = true
-			retries--
+		wait, ok := policy.nextWait(attempts, start, retryAfter)
+		if !ok {
+			return nil, lastErr
 		}
-
-		if doRetry {
-			continue
+		log.Debugf("retrying %s %s in %s (attempt %d of %d)", req.Method, req.URL.Path, wait, attempts+1, policy.MaxAttempts)
+		if err := policy.Clock.Sleep(ctx, wait); err != nil {
+			return nil, err
 		}
-
-		// no retry attempts, just return the err
-		return nil, respErr
 	}
 
-	c.logResponse(resp)
+	log = logWith(log, slog.Int("attempt", attempts), slog.Int("status", resp.StatusCode))
+	c.logResponse(log, resp)
 	defer resp.Body.Close()
 
+	if read, ok := v.(responseReader); ok {
+		if err := read(resp.Body); err != nil {
+			log.Errorf("error reading response: %v", err)
+			return nil, err
+		}
+		return resp.Header, nil
+	}
 	if v != nil {
-		decoder := json.NewDecoder(resp.Body)
-		// decoder.DisallowUnknownFields()
-		err := decoder.Decode(v)
+		mode := c.decodeMode
+		if ctx.Value(lenientDecodingKey{}) != nil {
+			mode = DecodeLenient
+		}
+		var drift *DriftReport
+		var err error
+		if mode == DecodeLenient {
+			err = json.NewDecoder(resp.Body).Decode(v)
+		} else {
+			var body []byte
+			drift, body, err = decodeWithDrift(resp.Body, v, &c.redaction)
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+		}
 		if err != nil {
-			c.log.Errorf("response headers: %v", resp.Header)
-			c.log.Errorf("error decoding %+v: %v", v, err)
-			c.logBodyError(&resp.Body)
+			log.Errorf("response headers: %v", c.redaction.header(resp.Header))
+			log.Errorf("error decoding %T: %v", v, err)
+			c.logBodyError(log, &resp.Body)
 			return nil, err
 		}
+		LocalizeTimes(v, c.StoreLocation())
+		if drift != nil {
+			call.Drift = drift
+			log.Warnf("schema drift decoding %T: %s", v, drift)
+			if mode == DecodeStrict && drift.strict() {
+				return nil, &DriftError{Method: req.Method, Path: req.URL.Path, Report: drift}
+			}
+		}
 	}
 
 	return resp.Header, nil
 }
 
+// responseReader is passed to do in place of a value to decode into, to
+// read the body of a successful response itself, e.g. to stream it.
+type responseReader func(body io.Reader) error
+
+// rewindRequest prepares req to be sent again: the body is restored and
+// OAuth signed requests get a fresh nonce, since WooCommerce rejects reuse.
+func (c *Client) rewindRequest(req *http.Request) error {
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return err
+		}
+		req.Body = body
+	}
+	if c.oauth1 != nil {
+		return c.oauth1.sign(req, c.app.CustomerKey, c.app.CustomerSecret)
+	}
+	return nil
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+var errNoResponse = errors.New("woocommerce: middleware returned neither a response nor an error")
+
 // ResponseDecodingError occurs when the response body from WooCommerce could
 // not be parsed.
 type ResponseDecodingError struct {
@@ -220,117 +379,113 @@ func (e ResponseDecodingError) Error() string {
 	return e.Message
 }
 
+// Is matches the sentinel errors for the response status, e.g. an HTML 404 page.
+func (e ResponseDecodingError) Is(target error) bool {
+	return errorMatches(target, e.Status, "", false)
+}
+
+// CheckResponseError returns the error described by r, or nil for a 2xx
+// response. It logs with the package default logger; requests sent by a
+// Client use the client's logger and redaction instead.
 func CheckResponseError(r *http.Response) error {
+	return checkResponseError(r, log, &defaultRedaction)
+}
+
+func checkResponseError(r *http.Response, log LeveledLoggerInterface, redaction *Redaction) error {
 	if http.StatusOK <= r.StatusCode && r.StatusCode < http.StatusMultipleChoices {
 		return nil
 	}
 
-	// Create an anonoymous struct to parse the JSON data into.
-	woocommerceError := struct {
-		Code    string      `json:"code"`
-		Message string      `json:"message"`
-		Status  string      `json:"status"`
-		Error   string      `json:"error"`
-		Data    interface{} `json:"data"`
-	}{}
+	responseError := ResponseError{Status: r.StatusCode}
+	if r.Request != nil && r.Request.URL != nil {
+		responseError.Method = r.Request.Method
+		responseError.Path = r.Request.URL.Path
+	}
 
 	bodyBytes, err := io.ReadAll(r.Body)
 	if err != nil {
-		return ResponseError{
-			Status:  r.StatusCode,
-			Message: err.Error(),
-		}
+		responseError.Message = err.Error()
+		return responseError
 	}
 
 	// empty body, this probably means WooCommerce returned an error with no body
 	// we'll handle that error in wrapSpecificError()
 	if len(bodyBytes) > 0 {
-		err := json.Unmarshal(bodyBytes, &woocommerceError)
-		if err != nil {
-			log.Errorf("CheckResponseError unmarshall: '%v' %v", string(bodyBytes), err)
+		// WordPress REST API error, e.g.
+		// {"code":"rest_invalid_param","message":"Invalid parameter(s): status","data":{"status":400,"params":{"status":"..."}}}
+		woocommerceError := struct {
+			Code    string          `json:"code"`
+			Message string          `json:"message"`
+			Data    json.RawMessage `json:"data"`
+		}{}
+		if err := json.Unmarshal(bodyBytes, &woocommerceError); err != nil {
+			log.Errorf("CheckResponseError unmarshall: '%s' %v", redaction.body(bodyBytes), err)
 			return ResponseDecodingError{
 				Body:    bodyBytes,
 				Message: err.Error(),
 				Status:  r.StatusCode,
 			}
-		} else {
-			log.Errorf("CheckResponseError response error '%v': %v", string(bodyBytes), woocommerceError)
-			return ResponseError{
-				Status:  r.StatusCode,
-				Message: woocommerceError.Message,
-			}
 		}
+		responseError.Code = woocommerceError.Code
+		responseError.Message = woocommerceError.Message
+		responseError.Params = errorParams(woocommerceError.Data)
+		for _, param := range slices.Sorted(maps.Keys(responseError.Params)) {
+			responseError.Data = append(responseError.Data, param+": "+responseError.Params[param])
+		}
+		log.Errorf("CheckResponseError response error '%s': %s", redaction.body(bodyBytes), woocommerceError.Message)
 	}
 
-	// Create the response error from the WooCommerce error.
-	responseError := ResponseError{
-		Status:  r.StatusCode,
-		Message: woocommerceError.Message,
-	}
-	log.Errorf("CheckResponseError, generic: %v", responseError)
-
-	// If the errors field is not filled out, we can return here.
-	if woocommerceError.Message == "" {
-		return wrapSpecificError(r, responseError)
-	}
-
-	// 	switch reflect.TypeOf(woocommerceError.Errors).Kind() {
-	// 	case reflect.String:
-	// 		// Single string, use as message
-	// 		responseError.Message = woocommerceError.Errors.(string)
-	// 	case reflect.Slice:
-	// 		// An array, parse each entry as a string and join them on the message
-	// 		// json always serializes JSON arrays into []interface{}
-	// 		for _, elem := range woocommerceError.Errors.([]interface{}) {
-	// 			responseError.Data = append(responseError.Data, fmt.Sprint(elem))
-	// 		}
-	// 		responseError.Message = strings.Join(responseError.Data, ", ")
-	// 	case reflect.Map:
-	// 		// A map, parse each error for each key in the map.
-	// 		// json always serializes into map[string]interface{} for objects
-	// 		for k, v := range woocommerceError.Errors.(map[string]interface{}) {
-	// 			// Check to make sure the interface is a slice
-	// 			// json always serializes JSON arrays into []interface{}
-	// 			if reflect.TypeOf(v).Kind() == reflect.Slice {
-	// 				for _, elem := range v.([]interface{}) {
-	// 					// If the primary message of the response error is not set, use
-	// 					// any message.
-	// 					if responseError.Message == "" {
-	// 						responseError.Message = fmt.Sprintf("%v: %v", k, elem)
-	// 					}
-	// 					topicAndElem := fmt.Sprintf("%v: %v", k, elem)
-	// 					responseError.Data = append(responseError.Data, topicAndElem)
-	// 				}
-	// 			}
-	// 		}
-	// 	}
-	log.Errorf("CheckResponseError: %v", responseError)
-	return wrapSpecificError(r, responseError)
-}
-
-func (c *Client) logRequest(req *http.Request) {
+	return wrapSpecificError(r, responseError, log)
+}
+
+// errorParams reads the invalid parameters of an error's data, given either
+// as {"param": "reason"} by rest_invalid_param or as ["param"] by
+// rest_missing_callback_param.
+func errorParams(data json.RawMessage) map[string]string {
+	var d struct {
+		Params json.RawMessage `json:"params"`
+	}
+	if len(data) == 0 || json.Unmarshal(data, &d) != nil || len(d.Params) == 0 {
+		return nil
+	}
+	var reasons map[string]string
+	if json.Unmarshal(d.Params, &reasons) == nil && len(reasons) > 0 {
+		return reasons
+	}
+	var names []string
+	if json.Unmarshal(d.Params, &names) == nil && len(names) > 0 {
+		params := make(map[string]string, len(names))
+		for _, name := range names {
+			params[name] = "missing"
+		}
+		return params
+	}
+	return nil
+}
+
+func (c *Client) logRequest(log LeveledLoggerInterface, req *http.Request) {
 	if req == nil {
 		return
 	}
 	if req.URL != nil {
-		c.log.Debugf("%s: %s", req.Method, req.URL.String())
-		c.log.Debugf("%s", req.Header)
+		log.Debugf("%s: %s", req.Method, c.redaction.url(req.URL))
+		log.Debugf("%s", c.redaction.header(req.Header))
 	}
-	c.logBody(&req.Body, "SENT: %s")
+	c.logBody(log, &req.Body, "SENT: %s")
 }
 
-func (c *Client) logResponse(res *http.Response) {
+func (c *Client) logResponse(log LeveledLoggerInterface, res *http.Response) {
 	if res == nil {
 		return
 	}
-	c.log.Debugf("RECV %d: %s", res.StatusCode, res.Status)
-	c.logBody(&res.Body, "RESP: %s")
+	log.Debugf("RECV %d: %s", res.StatusCode, res.Status)
+	c.logBody(log, &res.Body, "RESP: %s")
 }
 
-const maxLogBodySize = 256 // 4KB max for debug body logging
+const maxLogBodySize = 256       // 4KB max for debug body logging
 const maxLogBodyErrorSize = 8192 // 8KB max for error body logging
 
-func (c *Client) logBody(body *io.ReadCloser, format string) {
+func (c *Client) logBody(log LeveledLoggerInterface, body *io.ReadCloser, format string) {
 	if body == nil || *body == nil {
 		return
 	}
@@ -349,17 +504,18 @@ func (c *Client) logBody(body *io.ReadCloser, format string) {
 			logData = head[:maxLogBodySize]
 			truncated = "... [TRUNCATED]"
 		}
+		logData = c.redaction.body(logData)
 		buf := bytes.Buffer{}
 		if err := json.Indent(&buf, logData, "", " "); err != nil {
 			// Not valid JSON or truncated mid-token; log raw
-			c.log.Debugf(format, string(logData)+truncated)
+			log.Debugf(format, string(logData)+truncated)
 		} else {
-			c.log.Debugf(format, buf.String()+truncated)
+			log.Debugf(format, buf.String()+truncated)
 		}
 	}
 }
 
-func (c *Client) logBodyError(body *io.ReadCloser) {
+func (c *Client) logBodyError(log LeveledLoggerInterface, body *io.ReadCloser) {
 	if body == nil || *body == nil {
 		return
 	}
@@ -377,28 +533,81 @@ func (c *Client) logBodyError(body *io.ReadCloser) {
 			logData = head[:maxLogBodyErrorSize]
 			truncated = "... [TRUNCATED]"
 		}
+		logData = c.redaction.body(logData)
 		buf := bytes.Buffer{}
 		if err := json.Indent(&buf, logData, "", " "); err != nil {
-			c.log.Errorf("Error: %s%s", string(logData), truncated)
+			log.Errorf("Error: %s%s", string(logData), truncated)
 		} else {
-			c.log.Errorf("Error: %s%s", buf.String(), truncated)
+			log.Errorf("Error: %s%s", buf.String(), truncated)
 		}
 	} else {
-		c.log.Errorf("Error: body is EMPTY")
+		log.Errorf("Error: body is EMPTY")
 	}
 }
 
+// Sentinel errors matched by errors.Is against the errors returned for API
+// responses, e.g. errors.Is(err, ErrNotFound).
+var (
+	ErrNotFound     = errors.New("woocommerce: not found")
+	ErrUnauthorized = errors.New("woocommerce: unauthorized")
+	ErrForbidden    = errors.New("woocommerce: forbidden")
+	ErrInvalidParam = errors.New("woocommerce: invalid parameter")
+	ErrRateLimited  = errors.New("woocommerce: rate limited")
+)
+
 // ResponseError is A general response error that follows a similar layout to WooCommerce's response
 // errors, i.e. either a single message or a list of messages.
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#request-response-format
 type ResponseError struct {
 	Status  int
 	Message string
-	Data    []string
+	// Data lists the invalid parameters as "param: reason".
+	Data []string
+	// Code is the WooCommerce error code, e.g. woocommerce_rest_shop_order_invalid_id.
+	Code string
+	// Params maps each invalid parameter to the reason it was rejected.
+	Params map[string]string
+	// Method and Path are those of the failed request.
+	Method string
+	Path   string
 }
 
 func (e ResponseError) Error() string {
-	return fmt.Sprintf("%v: %v [%v]", e.Status, e.Message, e.Data)
+	var b strings.Builder
+	if e.Method != "" {
+		fmt.Fprintf(&b, "%s %s: ", e.Method, e.Path)
+	}
+	fmt.Fprintf(&b, "%v", e.Status)
+	if e.Code != "" {
+		fmt.Fprintf(&b, " %s", e.Code)
+	}
+	fmt.Fprintf(&b, ": %v", e.Message)
+	if len(e.Data) > 0 {
+		fmt.Fprintf(&b, " [%s]", strings.Join(e.Data, "; "))
+	}
+	return b.String()
+}
+
+// Is matches the sentinel errors for the response status and code.
+func (e ResponseError) Is(target error) bool {
+	return errorMatches(target, e.Status, e.Code, len(e.Params) > 0)
+}
+
+// errorMatches reports whether an API error with status and code is target.
+func errorMatches(target error, status int, code string, invalidParams bool) bool {
+	switch target {
+	case ErrNotFound:
+		return status == http.StatusNotFound || strings.HasSuffix(code, "_invalid_id")
+	case ErrUnauthorized:
+		return status == http.StatusUnauthorized
+	case ErrForbidden:
+		return status == http.StatusForbidden
+	case ErrInvalidParam:
+		return code == "rest_invalid_param" || code == "rest_missing_callback_param" || invalidParams
+	case ErrRateLimited:
+		return status == http.StatusTooManyRequests
+	}
+	return false
 }
 
 // An error specific to a rate-limiting response. Embeds the ResponseError to
@@ -408,7 +617,12 @@ type RateLimitError struct {
 	RetryAfter int
 }
 
-func wrapSpecificError(r *http.Response, err ResponseError) error {
+// Unwrap returns the embedded ResponseError, so errors.As finds it.
+func (e RateLimitError) Unwrap() error {
+	return e.ResponseError
+}
+
+func wrapSpecificError(r *http.Response, err ResponseError, log LeveledLoggerInterface) error {
 	if err.Status == http.StatusTooManyRequests {
 		f, _ := strconv.ParseFloat(r.Header.Get("Retry-After"), 64)
 		return RateLimitError{
@@ -426,7 +640,12 @@ func wrapSpecificError(r *http.Response, err ResponseError) error {
 // CreateAndDo performs a web request to WooCommerce with the given method (GET,
 // POST, PUT, DELETE) and relative path (e.g. "/wp-admin/v3").
 func (c *Client) CreateAndDo(method, relPath string, data, options, resource interface{}) error {
-	_, err := c.createAndDoGetHeaders(method, relPath, data, options, resource)
+	return c.CreateAndDoWithContext(context.Background(), method, relPath, data, options, resource)
+}
+
+// CreateAndDoWithContext is like CreateAndDo but the request is bound to ctx.
+func (c *Client) CreateAndDoWithContext(ctx context.Context, method, relPath string, data, options, resource interface{}) error {
+	_, err := c.createAndDoGetHeaders(ctx, method, relPath, data, options, resource)
 	if err != nil {
 		return err
 	}
@@ -434,8 +653,8 @@ func (c *Client) CreateAndDo(method, relPath string, data, options, resource int
 }
 
 // createAndDoGetHeaders creates an executes a request while returning the response headers.
-func (c *Client) createAndDoGetHeaders(method, relPath string, data, options, resource interface{}) (http.Header, error) {
-	req, err := c.NewAPIRequest(method, relPath, data, options)
+func (c *Client) createAndDoGetHeaders(ctx context.Context, method, relPath string, data, options, resource interface{}) (http.Header, error) {
+	req, err := c.NewAPIRequestWithContext(ctx, method, relPath, data, options)
 	if err != nil {
 		c.log.Errorf("Error creating request: %s", err)
 		return nil, err
@@ -446,11 +665,16 @@ func (c *Client) createAndDoGetHeaders(method, relPath string, data, options, re
 // NewAPIRequest creates an HTTP request with the API path prefix prepended.
 // Use this instead of NewRequest when calling WooCommerce API endpoints.
 func (c *Client) NewAPIRequest(method, relPath string, body, options interface{}) (*http.Request, error) {
+	return c.NewAPIRequestWithContext(context.Background(), method, relPath, body, options)
+}
+
+// NewAPIRequestWithContext is like NewAPIRequest but the request is bound to ctx.
+func (c *Client) NewAPIRequestWithContext(ctx context.Context, method, relPath string, body, options interface{}) (*http.Request, error) {
 	if strings.HasPrefix(relPath, "/") {
 		relPath = strings.TrimLeft(relPath, "/")
 	}
 	relPath = path.Join(c.pathPrefix, relPath)
-	return c.NewRequest(method, relPath, body, options)
+	return c.NewRequestWithContext(ctx, method, relPath, body, options)
 }
 
 // Creates an API request. A relative URL can be provided in urlStr, which will
@@ -458,7 +682,11 @@ func (c *Client) NewAPIRequest(method, relPath string, body, options interface{}
 // specified without a preceding slash. If specified, the value pointed to by
 // body is JSON encoded and included as the request body.
 func (c *Client) NewRequest(method, relPath string, body, options interface{}) (*http.Request, error) {
-	// fmt.Println("DEBUG: NewRequest called for", relPath) 
+	return c.NewRequestWithContext(context.Background(), method, relPath, body, options)
+}
+
+// NewRequestWithContext is like NewRequest but the request is bound to ctx.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, relPath string, body, options interface{}) (*http.Request, error) {
 	rel, err := url.Parse(relPath)
 	if err != nil {
 		return nil, err
@@ -467,9 +695,9 @@ func (c *Client) NewRequest(method, relPath string, body, options interface{}) (
 	// Make the full url based on the relative path
 	u := c.baseURL.ResolveReference(rel)
 
-	// Add custom options
+	// Add custom options, either a struct with url tags or url.Values
 	if options != nil {
-		optionsQuery, err := query.Values(options)
+		optionsQuery, err := optionValues(options)
 		if err != nil {
 			return nil, err
 		}
@@ -486,24 +714,29 @@ func (c *Client) NewRequest(method, relPath string, body, options interface{}) (
 	var js []byte = nil
 
 	if body != nil {
-		js, err = json.Marshal(body)
+		js, err = json.Marshal(inStoreTimes(body, c.StoreLocation()))
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	req, err := http.NewRequest(method, u.String(), bytes.NewBuffer(js))
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewBuffer(js))
 	if err != nil {
-		log.Errorf("request failed %q: %s %s %q", err.Error(), method, u.String(), js)
+		c.log.Errorf("request failed %q: %s %s %s", err.Error(), method, c.redaction.url(u), c.redaction.body(js))
 		return nil, err
 	}
 
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("User-Agent", UserAgent)
-	if c.app.JwtToken != "" {
+	switch {
+	case c.oauth1 != nil:
+		if err := c.oauth1.sign(req, c.app.CustomerKey, c.app.CustomerSecret); err != nil {
+			return nil, err
+		}
+	case c.app.JwtToken != "":
 		req.Header.Add("Authorization", "Bearer "+c.app.JwtToken)
-	} else {
+	default:
 		req.SetBasicAuth(c.app.CustomerKey, c.app.CustomerSecret)
 	}
 	return req, nil
@@ -512,24 +745,44 @@ func (c *Client) NewRequest(method, relPath string, body, options interface{}) (
 // Get performs a GET request for the given path and saves the result in the
 // given resource.
 func (c *Client) Get(path string, resource, options interface{}) error {
-	return c.CreateAndDo("GET", path, nil, options, resource)
+	return c.GetWithContext(context.Background(), path, resource, options)
+}
+
+// GetWithContext is like Get but the request is bound to ctx.
+func (c *Client) GetWithContext(ctx context.Context, path string, resource, options interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "GET", path, nil, options, resource)
 }
 
 // Post performs a POST request for the given path and saves the result in the
 // given resource.
 func (c *Client) Post(path string, data, resource interface{}) error {
-	return c.CreateAndDo("POST", path, data, nil, resource)
+	return c.PostWithContext(context.Background(), path, data, resource)
+}
+
+// PostWithContext is like Post but the request is bound to ctx.
+func (c *Client) PostWithContext(ctx context.Context, path string, data, resource interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "POST", path, data, nil, resource)
 }
 
 // Put performs a PUT request for the given path and saves the result in the
 // given resource.
 func (c *Client) Put(path string, data, resource interface{}) error {
-	return c.CreateAndDo("PUT", path, data, nil, resource)
+	return c.PutWithContext(context.Background(), path, data, resource)
+}
+
+// PutWithContext is like Put but the request is bound to ctx.
+func (c *Client) PutWithContext(ctx context.Context, path string, data, resource interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "PUT", path, data, nil, resource)
 }
 
 // Delete performs a DELETE request for the given path
 func (c *Client) Delete(path string, options, resource interface{}) error {
-	return c.CreateAndDo("DELETE", path, nil, options, resource)
+	return c.DeleteWithContext(context.Background(), path, options, resource)
+}
+
+// DeleteWithContext is like Delete but the request is bound to ctx.
+func (c *Client) DeleteWithContext(ctx context.Context, path string, options, resource interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "DELETE", path, nil, options, resource)
 }
 
 // ListOptions represent ist options that can be used for most collections of entities.
@@ -545,6 +798,10 @@ type ListOptions struct {
 	Offset  int     `url:"offset,omitempty"`
 	Order   string  `url:"order,omitempty"`
 	Orderby string  `url:"orderby,omitempty"`
+	// Fields limits the objects returned to the given top level fields.
+	Fields []string `url:"_fields,omitempty,comma"`
+	// Embed includes the embeddable linked resources in each object's Embedded.
+	Embed bool `url:"_embed,omitempty"`
 }
 
 // DeleteOption is the only option for delete order record. dangerous
@@ -553,5 +810,5 @@ type ListOptions struct {
 // but the order's status became to be trash.
 // it is better to setting force's column value be "false" rather then  "true"
 type DeleteOption struct {
-	Force bool `json:"force,omitempty"`
+	Force bool `json:"force,omitempty" url:"force,omitempty"`
 }
@@ -0,0 +1,215 @@
+package woocommerce
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+// newTestClient returns a client talking to an httptest server backed by h.
+// Logging is silenced so test output only shows failures.
+func newTestClient(t *testing.T, h http.Handler, opts ...Option) *Client {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	app := App{
+		CustomerKey:    customerKey,
+		CustomerSecret: customerSecret,
+	}
+	opts = append([]Option{WithLog(&LeveledLogger{})}, opts...)
+	return NewClient(app, srv.URL, opts...)
+}
+
+func TestClient_GetWithContext_Canceled(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.Order.GetWithContext(ctx, 1, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestClient_RetryWaitHonoursContext(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}), WithRetry(3))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.Product.ListWithContext(ctx, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Fatalf("retry wait ignored context cancellation")
+	}
+}
+
+func TestClient_ListWithContext(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wp-json/wc/v3/orders" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("X-WP-Total", "2")
+		w.Header().Set("X-WP-TotalPages", "1")
+		w.Write([]byte(`[{"id":1},{"id":2}]`))
+	}))
+
+	orders, err := c.Order.ListWithContext(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 2 || orders[1].ID != 2 {
+		t.Fatalf("unexpected orders %+v", orders)
+	}
+}
+
+func TestClient_ResponseError(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		code     string
+		params   map[string]string
+		is       []error
+		isNot    []error
+		rateErr  bool
+		contains string
+	}{
+		{
+			name:     "invalid id",
+			status:   http.StatusNotFound,
+			body:     `{"code":"woocommerce_rest_shop_order_invalid_id","message":"Invalid ID.","data":{"status":404}}`,
+			code:     "woocommerce_rest_shop_order_invalid_id",
+			is:       []error{ErrNotFound},
+			isNot:    []error{ErrInvalidParam, ErrUnauthorized},
+			contains: "GET /wp-json/wc/v3/orders/7: 404 woocommerce_rest_shop_order_invalid_id: Invalid ID.",
+		},
+		{
+			name:     "invalid param",
+			status:   http.StatusBadRequest,
+			body:     `{"code":"rest_invalid_param","message":"Invalid parameter(s): status","data":{"status":400,"params":{"status":"status is not one of pending, processing."},"details":{}}}`,
+			code:     "rest_invalid_param",
+			params:   map[string]string{"status": "status is not one of pending, processing."},
+			is:       []error{ErrInvalidParam},
+			isNot:    []error{ErrNotFound},
+			contains: "[status: status is not one of pending, processing.]",
+		},
+		{
+			name:   "missing param",
+			status: http.StatusBadRequest,
+			body:   `{"code":"rest_missing_callback_param","message":"Missing parameter(s): id","data":{"status":400,"params":["id"]}}`,
+			code:   "rest_missing_callback_param",
+			params: map[string]string{"id": "missing"},
+			is:     []error{ErrInvalidParam},
+		},
+		{
+			name:   "unauthorized",
+			status: http.StatusUnauthorized,
+			body:   `{"code":"woocommerce_rest_cannot_view","message":"Sorry, you cannot view this resource.","data":{"status":401}}`,
+			code:   "woocommerce_rest_cannot_view",
+			is:     []error{ErrUnauthorized},
+			isNot:  []error{ErrForbidden},
+		},
+		{
+			name:   "forbidden",
+			status: http.StatusForbidden,
+			body:   `{"code":"rest_forbidden","message":"Forbidden.","data":{"status":403}}`,
+			code:   "rest_forbidden",
+			is:     []error{ErrForbidden},
+		},
+		{
+			name:    "rate limited with body",
+			status:  http.StatusTooManyRequests,
+			body:    `{"code":"rate_limit_exceeded","message":"Too many requests.","data":{"status":429}}`,
+			code:    "rate_limit_exceeded",
+			is:      []error{ErrRateLimited},
+			rateErr: true,
+		},
+		{
+			name:   "html not found",
+			status: http.StatusNotFound,
+			body:   `<html>Not Found</html>`,
+			is:     []error{ErrNotFound},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", "3")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+
+			_, err := c.Order.GetWithContext(context.Background(), 7, nil)
+			for _, target := range tt.is {
+				if !errors.Is(err, target) {
+					t.Errorf("errors.Is(%v, %v) = false", err, target)
+				}
+			}
+			for _, target := range tt.isNot {
+				if errors.Is(err, target) {
+					t.Errorf("errors.Is(%v, %v) = true", err, target)
+				}
+			}
+			var rateErr RateLimitError
+			if errors.As(err, &rateErr) != tt.rateErr {
+				t.Errorf("RateLimitError = %v, want %v", !tt.rateErr, tt.rateErr)
+			} else if tt.rateErr && rateErr.RetryAfter != 3 {
+				t.Errorf("RetryAfter = %d", rateErr.RetryAfter)
+			}
+			if tt.code == "" {
+				return
+			}
+			var respErr ResponseError
+			if !errors.As(err, &respErr) {
+				t.Fatalf("expected ResponseError, got %T", err)
+			}
+			if respErr.Code != tt.code || respErr.Method != http.MethodGet || respErr.Path != "/wp-json/wc/v3/orders/7" {
+				t.Errorf("unexpected error %+v", respErr)
+			}
+			if len(respErr.Params) != len(tt.params) {
+				t.Errorf("Params = %v, want %v", respErr.Params, tt.params)
+			}
+			for k, v := range tt.params {
+				if respErr.Params[k] != v {
+					t.Errorf("Params[%s] = %q, want %q", k, respErr.Params[k], v)
+				}
+			}
+			if tt.contains != "" && !strings.Contains(err.Error(), tt.contains) {
+				t.Errorf("Error() = %q, want it to contain %q", err.Error(), tt.contains)
+			}
+		})
+	}
+}
+
+func TestListOptions_Dp(t *testing.T) {
+	for _, options := range []interface{}{
+		OrderListOption{Dp: 2},
+		SubscriptionOrderListOptions{Dp: 2},
+		CustomerListOption{Dp: 2},
+	} {
+		v, err := query.Values(options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.Get("dp") != "2" || v.Has("id") {
+			t.Errorf("%T encoded as %s", options, v.Encode())
+		}
+	}
+}